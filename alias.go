@@ -0,0 +1,110 @@
+package ints
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Alias is a table for drawing indices with probability proportional to
+// integer weights in constant time, using Vose's alias method. The table is
+// built with integer arithmetic so the probabilities are exactly
+// weights[i] / Sum(weights) with no rounding bias.
+type Alias struct {
+	total uint64
+	prob  []uint64 // threshold in [0, total] for keeping the column
+	alias []int
+}
+
+// NewAlias builds an alias table from weights. It panics if any weight is
+// negative, if all weights are zero, or if len(weights) times the sum of
+// the weights overflows 64 bits.
+func NewAlias(weights []int) *Alias {
+	n := uint64(len(weights))
+	var total uint64
+	for _, w := range weights {
+		if w < 0 {
+			panic(invalidArgument("negative weight"))
+		}
+		var carry uint64
+		total, carry = bits.Add64(total, uint64(w), 0)
+		if carry != 0 {
+			panic(ErrOverflow)
+		}
+	}
+	if total == 0 {
+		panic(invalidArgument("weights sum to zero"))
+	}
+	if hi, _ := bits.Mul64(total, n); hi != 0 {
+		panic(ErrOverflow)
+	}
+
+	// Each of the n columns holds a mass of total. Weight i starts with a
+	// mass of n*weights[i]; columns with too little mass are topped up
+	// from columns with too much.
+	a := &Alias{
+		total: total,
+		prob:  make([]uint64, n),
+		alias: make([]int, n),
+	}
+	var small, large []int
+	for i, w := range weights {
+		a.prob[i] = uint64(w) * n
+		a.alias[i] = i
+		if a.prob[i] < total {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		a.alias[s] = l
+		a.prob[l] -= total - a.prob[s]
+		if a.prob[l] < total {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// With exact arithmetic the remaining columns are exactly full.
+	for _, i := range large {
+		a.prob[i] = total
+	}
+	return a
+}
+
+// Len returns the number of weights in the table.
+func (a *Alias) Len() int {
+	return len(a.prob)
+}
+
+// Draw returns an index drawn with probability proportional to its weight
+// using values from src.
+func (a *Alias) Draw(src Source) int {
+	i := uintn(src, uint64(len(a.prob)))
+	if uintn(src, a.total) < a.prob[i] {
+		return int(i)
+	}
+	return a.alias[i]
+}
+
+// DrawCumulative returns an index drawn with probability proportional to
+// its weight, where cum holds the cumulative sum of non-negative weights as
+// computed by CumSum. Each draw takes O(log n) time, and changing a weight
+// only requires recomputing cum, which makes DrawCumulative preferable to
+// an Alias table when the weights change often. DrawCumulative panics if
+// cum is empty or the weights sum to zero.
+func DrawCumulative(src Source, cum []int) int {
+	if len(cum) == 0 {
+		panic(ErrEmpty)
+	}
+	if cum[len(cum)-1] <= 0 {
+		panic(invalidArgument("weights sum to zero"))
+	}
+	u := int(uintn(src, uint64(cum[len(cum)-1])))
+	// The first index whose cumulative weight exceeds u. Indices with zero
+	// weight share their cumulative sum with an earlier index and are
+	// never chosen.
+	return sort.Search(len(cum), func(i int) bool { return cum[i] > u })
+}
@@ -0,0 +1,73 @@
+package ints
+
+import (
+	"math"
+	"testing"
+)
+
+// checkDraws draws n indices and checks that each is drawn in proportion
+// to its weight.
+func checkDraws(t *testing.T, name string, weights []int, draw func() int) {
+	const n = 60000
+	counts := make([]int, len(weights))
+	for i := 0; i < n; i++ {
+		counts[draw()]++
+	}
+	total := Sum(weights)
+	for i, w := range weights {
+		want := float64(n) * float64(w) / float64(total)
+		if math.Abs(float64(counts[i])-want) > 5*math.Sqrt(want)+1 {
+			t.Errorf("%v: index %v drawn %v times, want about %v", name, i, counts[i], want)
+		}
+	}
+}
+
+func TestAlias(t *testing.T) {
+	weights := []int{1, 0, 3, 6, 2, 0, 8}
+	a := NewAlias(weights)
+	if a.Len() != len(weights) {
+		t.Errorf("Wrong length %v", a.Len())
+	}
+	src := NewSplitMix(11)
+	checkDraws(t, "Alias", weights, func() int { return a.Draw(src) })
+
+	// The probabilities are exact: the mass of every index, summed over
+	// the columns, equals n times its weight.
+	mass := make([]uint64, len(weights))
+	for i := range a.prob {
+		mass[i] += a.prob[i]
+		mass[a.alias[i]] += a.total - a.prob[i]
+	}
+	for i, w := range weights {
+		if mass[i] != uint64(w*len(weights)) {
+			t.Errorf("Index %v has mass %v, want %v", i, mass[i], w*len(weights))
+		}
+	}
+
+	if NewAlias([]int{0, 5, 0}).Draw(src) != 1 {
+		t.Errorf("Single non-zero weight not drawn")
+	}
+	if !Panics(func() { NewAlias([]int{1, -1}) }) {
+		t.Errorf("Did not panic with negative weight")
+	}
+	if !Panics(func() { NewAlias([]int{0, 0}) }) {
+		t.Errorf("Did not panic with zero weights")
+	}
+	if !Panics(func() { NewAlias([]int{math.MaxInt, math.MaxInt, math.MaxInt}) }) {
+		t.Errorf("Did not panic with overflowing weights")
+	}
+}
+
+func TestDrawCumulative(t *testing.T) {
+	weights := []int{1, 0, 3, 6, 2, 0, 8}
+	cum := CumSum(make([]int, len(weights)), weights)
+	src := NewPCG(5, 0)
+	checkDraws(t, "DrawCumulative", weights, func() int { return DrawCumulative(src, cum) })
+
+	if !Panics(func() { DrawCumulative(src, nil) }) {
+		t.Errorf("Did not panic with no weights")
+	}
+	if !Panics(func() { DrawCumulative(src, []int{0, 0}) }) {
+		t.Errorf("Did not panic with zero weights")
+	}
+}
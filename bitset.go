@@ -0,0 +1,131 @@
+package ints
+
+import "math/bits"
+
+// ToBitset sets the bits of dst at the positions given in inds and clears
+// all other bits. Bit i is stored in dst[i/64] at position i%64. ToBitset
+// panics if an index is negative or not less than 64*len(dst).
+func ToBitset(dst []uint64, inds []int) []uint64 {
+	for i := range dst {
+		dst[i] = 0
+	}
+	for _, ind := range inds {
+		if ind < 0 || ind >= 64*len(dst) {
+			panic(ErrIndexOutOfRange)
+		}
+		dst[ind/64] |= 1 << (uint(ind) % 64)
+	}
+	return dst
+}
+
+// FromBitset returns the positions of the set bits of b in increasing order.
+// FromBitset will reslice dst to have 0 length, and will append the
+// positions to dst.
+func FromBitset(dst []int, b []uint64) []int {
+	dst = dst[:0]
+	for i, w := range b {
+		for w != 0 {
+			dst = append(dst, 64*i+bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+	return dst
+}
+
+// And performs element-wise bitwise and between s and t and stores the
+// value in s. It panics if the lengths of s and t are not equal.
+func And(s, t []int) {
+	if len(s) != len(t) {
+		panic(lengthError("And", "s, t", len(s), len(t)))
+	}
+	for i, val := range t {
+		s[i] &= val
+	}
+}
+
+// Or performs element-wise bitwise or between s and t and stores the value
+// in s. It panics if the lengths of s and t are not equal.
+func Or(s, t []int) {
+	if len(s) != len(t) {
+		panic(lengthError("Or", "s, t", len(s), len(t)))
+	}
+	for i, val := range t {
+		s[i] |= val
+	}
+}
+
+// Xor performs element-wise bitwise exclusive or between s and t and stores
+// the value in s. It panics if the lengths of s and t are not equal.
+func Xor(s, t []int) {
+	if len(s) != len(t) {
+		panic(lengthError("Xor", "s, t", len(s), len(t)))
+	}
+	for i, val := range t {
+		s[i] ^= val
+	}
+}
+
+// AndNot performs element-wise bit clear (s &^ t) and stores the value in
+// s. It panics if the lengths of s and t are not equal.
+func AndNot(s, t []int) {
+	if len(s) != len(t) {
+		panic(lengthError("AndNot", "s, t", len(s), len(t)))
+	}
+	for i, val := range t {
+		s[i] &^= val
+	}
+}
+
+// ShiftLeft shifts every element in s left by n bits.
+func ShiftLeft(n uint, s []int) {
+	for i := range s {
+		s[i] <<= n
+	}
+}
+
+// ShiftRight shifts every element in s right by n bits. The shift is
+// arithmetic, so negative elements remain negative.
+func ShiftRight(n uint, s []int) {
+	for i := range s {
+		s[i] >>= n
+	}
+}
+
+// PopCount stores the number of set bits of each element of s in dst.
+// Negative elements are counted in two's complement.
+// A panic will occur if lengths of arguments do not match.
+func PopCount(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("PopCount", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = bits.OnesCount(uint(val))
+	}
+	return dst
+}
+
+// LeadingZeros stores the number of leading zero bits of each element of s
+// in dst. The result is 0 for negative elements and bits.UintSize for zero.
+// A panic will occur if lengths of arguments do not match.
+func LeadingZeros(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("LeadingZeros", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = bits.LeadingZeros(uint(val))
+	}
+	return dst
+}
+
+// TrailingZeros stores the number of trailing zero bits of each element of
+// s in dst. The result is bits.UintSize for zero.
+// A panic will occur if lengths of arguments do not match.
+func TrailingZeros(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("TrailingZeros", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = bits.TrailingZeros(uint(val))
+	}
+	return dst
+}
@@ -0,0 +1,70 @@
+package ints
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestBitset(t *testing.T) {
+	inds := []int{0, 3, 63, 64, 130}
+	b := ToBitset([]uint64{7, 7, 7}, inds)
+	if b[0] != 1|1<<3|1<<63 || b[1] != 1 || b[2] != 1<<2 {
+		t.Errorf("Wrong bitset %x", b)
+	}
+	got := FromBitset([]int{5, 5}, b)
+	AreSlicesEqual(t, inds, got, "Round trip mismatch")
+
+	// Indices found by Find round trip through a mask
+	s := []int{3, 4, 1, 7, 5}
+	found, _ := Find(nil, func(v int) bool { return v > 3 }, s, -1)
+	AreSlicesEqual(t, found, FromBitset(nil, ToBitset(make([]uint64, 1), found)), "Find mask mismatch")
+
+	if !Panics(func() { ToBitset(make([]uint64, 1), []int{64}) }) {
+		t.Errorf("Did not panic with index past end of bitset")
+	}
+	if !Panics(func() { ToBitset(make([]uint64, 1), []int{-1}) }) {
+		t.Errorf("Did not panic with negative index")
+	}
+}
+
+func TestBitwise(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		f     func(s, t []int)
+		truth []int
+	}{
+		{"And", And, []int{0b1000, 0, -4}},
+		{"Or", Or, []int{0b1110, 5, -1}},
+		{"Xor", Xor, []int{0b0110, 5, 3}},
+		{"AndNot", AndNot, []int{0b0100, 0, 3}},
+	} {
+		s := []int{0b1100, 0, -1}
+		test.f(s, []int{0b1010, 5, -4})
+		AreSlicesEqual(t, test.truth, s, "Wrong "+test.name)
+		if !Panics(func() { test.f(make([]int, 2), make([]int, 3)) }) {
+			t.Errorf("%v did not panic with length mismatch", test.name)
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	s := []int{1, 3, -8}
+	ShiftLeft(2, s)
+	AreSlicesEqual(t, []int{4, 12, -32}, s, "Wrong left shift")
+	ShiftRight(3, s)
+	AreSlicesEqual(t, []int{0, 1, -4}, s, "Wrong right shift")
+}
+
+func TestBitCounts(t *testing.T) {
+	s := []int{0, 1, 12, -1}
+	dst := make([]int, len(s))
+	PopCount(dst, s)
+	AreSlicesEqual(t, []int{0, 1, 2, bits.UintSize}, dst, "Wrong PopCount")
+	LeadingZeros(dst, s)
+	AreSlicesEqual(t, []int{bits.UintSize, bits.UintSize - 1, bits.UintSize - 4, 0}, dst, "Wrong LeadingZeros")
+	TrailingZeros(dst, s)
+	AreSlicesEqual(t, []int{bits.UintSize, 0, 2, 0}, dst, "Wrong TrailingZeros")
+	if !Panics(func() { PopCount(make([]int, 2), s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
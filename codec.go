@@ -0,0 +1,125 @@
+package ints
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// The encoded form of a slice is the sequence of its deltas (as computed by
+// Diff), each mapped to an unsigned value with zigzag encoding so that small
+// negative deltas stay small, and written as an LEB128 varint. No length is
+// stored; a decoder reads until the input is exhausted.
+
+// zigzag maps signed integers to unsigned integers so that values of small
+// magnitude have small encodings: 0, -1, 1, -2, 2 become 0, 1, 2, 3, 4.
+func zigzag(v int) uint64 {
+	x := int64(v)
+	return uint64(x<<1) ^ uint64(x>>63)
+}
+
+// unzigzag is the inverse of zigzag.
+func unzigzag(u uint64) int {
+	return int(int64(u>>1) ^ -int64(u&1))
+}
+
+// AppendEncoded appends the delta, zigzag and varint encoding of s to dst
+// and returns the extended buffer. s is not modified.
+func AppendEncoded(dst []byte, s []int) []byte {
+	prev := 0
+	for _, val := range s {
+		dst = binary.AppendUvarint(dst, zigzag(val-prev))
+		prev = val
+	}
+	return dst
+}
+
+// DecodeInto decodes the values encoded in b by AppendEncoded or an Encoder.
+// DecodeInto will reslice dst to have 0 length, and will append the decoded
+// values to dst. If b is malformed, the values decoded before the error are
+// returned along with the error.
+func DecodeInto(dst []int, b []byte) ([]int, error) {
+	dst = dst[:0]
+	var err error
+	for len(b) > 0 {
+		u, n := binary.Uvarint(b)
+		if n == 0 {
+			err = io.ErrUnexpectedEOF
+			break
+		}
+		if n < 0 {
+			err = malformed("varint overflows 64 bits")
+			break
+		}
+		dst = append(dst, unzigzag(u))
+		b = b[n:]
+	}
+	// Undo the delta encoding in place
+	if len(dst) > 0 {
+		CumSum(dst, dst)
+	}
+	return dst, err
+}
+
+// Encoder writes a stream of delta encoded values to an io.Writer. The
+// deltas carry over between calls to Encode, so encoding a slice in several
+// pieces produces the same bytes as encoding it at once.
+type Encoder struct {
+	w    io.Writer
+	prev int
+	buf  []byte
+}
+
+// NewEncoder returns an Encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the encoding of the values in s.
+func (e *Encoder) Encode(s []int) error {
+	e.buf = e.buf[:0]
+	for _, val := range s {
+		e.buf = binary.AppendUvarint(e.buf, zigzag(val-e.prev))
+		e.prev = val
+	}
+	_, err := e.w.Write(e.buf)
+	return err
+}
+
+// Decoder reads a stream of values written by an Encoder or AppendEncoded.
+type Decoder struct {
+	r    io.ByteReader
+	prev int
+}
+
+// NewDecoder returns a Decoder reading from r. If r does not implement
+// io.ByteReader it is wrapped in a bufio.Reader, which may read past the
+// end of the encoded values.
+func NewDecoder(r io.Reader) *Decoder {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Decoder{r: br}
+}
+
+// Decode decodes up to len(dst) values into dst and returns the number of
+// values decoded. At the end of the stream Decode returns the values
+// read so far and io.EOF. A stream ending partway through a value returns
+// io.ErrUnexpectedEOF. Any other error wraps ErrMalformed, along with the
+// error that caused it.
+func (d *Decoder) Decode(dst []int) (int, error) {
+	for i := range dst {
+		u, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				err = fmt.Errorf("%w: %w", ErrMalformed, err)
+			}
+			return i, err
+		}
+		d.prev += unzigzag(u)
+		dst[i] = d.prev
+	}
+	return len(dst), nil
+}
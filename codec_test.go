@@ -0,0 +1,105 @@
+package ints
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestZigzag(t *testing.T) {
+	for _, test := range []struct {
+		v int
+		u uint64
+	}{
+		{0, 0},
+		{-1, 1},
+		{1, 2},
+		{-2, 3},
+		{2, 4},
+		{math.MaxInt64, math.MaxUint64 - 1},
+		{math.MinInt64, math.MaxUint64},
+	} {
+		if u := zigzag(test.v); u != test.u {
+			t.Errorf("zigzag(%v) = %v, want %v", test.v, u, test.u)
+		}
+		if v := unzigzag(test.u); v != test.v {
+			t.Errorf("unzigzag(%v) = %v, want %v", test.u, v, test.v)
+		}
+	}
+}
+
+func TestAppendEncoded(t *testing.T) {
+	s := []int{100, 101, 99, 99, math.MaxInt64, math.MinInt64, 0, -5}
+	b := AppendEncoded(nil, s)
+	got, err := DecodeInto(nil, b)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	AreSlicesEqual(t, s, got, "Round trip mismatch")
+
+	// Small deltas take a single byte each
+	b = AppendEncoded(b[:0], []int{1000, 1001, 1000, 1002})
+	if len(b) != 5 {
+		t.Errorf("Unexpected encoded length %v, want 5", len(b))
+	}
+
+	// Decoding reuses dst
+	dst := []int{9, 9, 9, 9, 9, 9}
+	dst, err = DecodeInto(dst, b)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	AreSlicesEqual(t, []int{1000, 1001, 1000, 1002}, dst, "Mismatch reusing dst")
+
+	got, err = DecodeInto(nil, nil)
+	if err != nil || len(got) != 0 {
+		t.Errorf("Empty input decoded to %v, %v", got, err)
+	}
+}
+
+func TestDecodeIntoMalformed(t *testing.T) {
+	b := AppendEncoded(nil, []int{1, 2, 300})
+	got, err := DecodeInto(nil, b[:len(b)-1])
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Truncated input returned error %v", err)
+	}
+	AreSlicesEqual(t, []int{1, 2}, got, "Values before truncation")
+
+	overflow := bytes.Repeat([]byte{0xff}, 11)
+	if _, err := DecodeInto(nil, overflow); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Overflowing varint returned %v", err)
+	}
+	if _, err := NewDecoder(bytes.NewReader(overflow)).Decode(make([]int, 1)); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Decoder with overflowing varint returned %v", err)
+	}
+}
+
+func TestEncoderDecoder(t *testing.T) {
+	s := []int{-3, 4, 1000000, 7, -5, 0, 0, 12}
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	if err := enc.Encode(s[:3]); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(s[3:]); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), AppendEncoded(nil, s)) {
+		t.Errorf("Streamed encoding differs from AppendEncoded")
+	}
+
+	dec := NewDecoder(io.MultiReader(&buf))
+	dst := make([]int, 5)
+	n, err := dec.Decode(dst)
+	if n != 5 || err != nil {
+		t.Errorf("First decode returned %v, %v", n, err)
+	}
+	AreSlicesEqual(t, s[:5], dst, "First decode")
+	n, err = dec.Decode(dst)
+	if n != 3 || err != io.EOF {
+		t.Errorf("Second decode returned %v, %v", n, err)
+	}
+	AreSlicesEqual(t, s[5:], dst[:n], "Second decode")
+}
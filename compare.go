@@ -0,0 +1,149 @@
+package ints
+
+// LessTo stores in dst whether each element of s is less than the
+// corresponding element of t. The mask can be used with Where and the
+// masked arithmetic functions, or packed with MaskToBitset.
+// A panic will occur if lengths of arguments do not match.
+func LessTo(dst []bool, s, t []int) []bool {
+	if len(dst) != len(s) || len(dst) != len(t) {
+		panic(lengthError("LessTo", "dst, s, t", len(dst), len(s), len(t)))
+	}
+	for i, val := range s {
+		dst[i] = val < t[i]
+	}
+	return dst
+}
+
+// LessConstTo stores in dst whether each element of s is less than c.
+// A panic will occur if the lengths of dst and s do not match.
+func LessConstTo(dst []bool, s []int, c int) []bool {
+	if len(dst) != len(s) {
+		panic(lengthError("LessConstTo", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = val < c
+	}
+	return dst
+}
+
+// EqualTo stores in dst whether each element of s is equal to the
+// corresponding element of t.
+// A panic will occur if lengths of arguments do not match.
+func EqualTo(dst []bool, s, t []int) []bool {
+	if len(dst) != len(s) || len(dst) != len(t) {
+		panic(lengthError("EqualTo", "dst, s, t", len(dst), len(s), len(t)))
+	}
+	for i, val := range s {
+		dst[i] = val == t[i]
+	}
+	return dst
+}
+
+// EqualConstTo stores in dst whether each element of s is equal to c.
+// A panic will occur if the lengths of dst and s do not match.
+func EqualConstTo(dst []bool, s []int, c int) []bool {
+	if len(dst) != len(s) {
+		panic(lengthError("EqualConstTo", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = val == c
+	}
+	return dst
+}
+
+// GreaterTo stores in dst whether each element of s is greater than the
+// corresponding element of t.
+// A panic will occur if lengths of arguments do not match.
+func GreaterTo(dst []bool, s, t []int) []bool {
+	if len(dst) != len(s) || len(dst) != len(t) {
+		panic(lengthError("GreaterTo", "dst, s, t", len(dst), len(s), len(t)))
+	}
+	for i, val := range s {
+		dst[i] = val > t[i]
+	}
+	return dst
+}
+
+// GreaterConstTo stores in dst whether each element of s is greater than c.
+// A panic will occur if the lengths of dst and s do not match.
+func GreaterConstTo(dst []bool, s []int, c int) []bool {
+	if len(dst) != len(s) {
+		panic(lengthError("GreaterConstTo", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = val > c
+	}
+	return dst
+}
+
+// MaskToBitset packs mask into dst, with element i of mask stored in
+// dst[i/64] at position i%64 as in ToBitset. The bits of dst past the end
+// of mask are cleared.
+// A panic will occur if len(dst) is not (len(mask)+63)/64.
+func MaskToBitset(dst []uint64, mask []bool) []uint64 {
+	if len(dst) != (len(mask)+63)/64 {
+		panic(lengthError("MaskToBitset", "dst, (len(mask)+63)/64", len(dst), (len(mask)+63)/64))
+	}
+	for i := range dst {
+		dst[i] = 0
+	}
+	for i, m := range mask {
+		if m {
+			dst[i/64] |= 1 << (uint(i) % 64)
+		}
+	}
+	return dst
+}
+
+// BitsetToMask unpacks the first len(dst) bits of b into dst.
+// A panic will occur if len(b) is not (len(dst)+63)/64.
+func BitsetToMask(dst []bool, b []uint64) []bool {
+	if len(b) != (len(dst)+63)/64 {
+		panic(lengthError("BitsetToMask", "b, (len(dst)+63)/64", len(b), (len(dst)+63)/64))
+	}
+	for i := range dst {
+		dst[i] = b[i/64]&(1<<(uint(i)%64)) != 0
+	}
+	return dst
+}
+
+// Any returns whether f returns true for any element of s. f is not called
+// after the first element for which it returns true. Any returns false if s
+// is empty.
+func Any(f func(int) bool, s []int) bool {
+	for _, val := range s {
+		if f(val) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns whether f returns true for every element of s. f is not
+// called after the first element for which it returns false. All returns
+// true if s is empty.
+func All(f func(int) bool, s []int) bool {
+	for _, val := range s {
+		if !f(val) {
+			return false
+		}
+	}
+	return true
+}
+
+// Where stores in dst the element of a where mask is true and the element
+// of b where it is false. dst may be the same slice as a or b.
+// A panic will occur if lengths of arguments do not match.
+func Where(dst []int, mask []bool, a, b []int) []int {
+	if len(dst) != len(mask) || len(dst) != len(a) || len(dst) != len(b) {
+		panic(lengthError("Where", "dst, mask, a, b", len(dst), len(mask), len(a), len(b)))
+	}
+	for i, m := range mask {
+		if m {
+			dst[i] = a[i]
+		} else {
+			dst[i] = b[i]
+		}
+	}
+	return dst
+}
@@ -0,0 +1,75 @@
+package ints
+
+import "testing"
+
+func areMasksEqual(t *testing.T, truth, comp []bool, str string) {
+	if len(truth) != len(comp) {
+		t.Errorf(str+": length mismatch. Expected %v, Found %v", truth, comp)
+		return
+	}
+	for i := range truth {
+		if truth[i] != comp[i] {
+			t.Errorf(str+". Expected %v, returned %v", truth, comp)
+			return
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	u := []int{3, 9, 0, 7, 2}
+	dst := make([]bool, len(s))
+	areMasksEqual(t, []bool{false, true, false, false, false}, LessTo(dst, s, u), "LessTo")
+	areMasksEqual(t, []bool{true, false, true, false, false}, LessConstTo(dst, s, 4), "LessConstTo")
+	areMasksEqual(t, []bool{true, false, false, true, false}, EqualTo(dst, s, u), "EqualTo")
+	areMasksEqual(t, []bool{false, false, false, true, false}, EqualConstTo(dst, s, 7), "EqualConstTo")
+	areMasksEqual(t, []bool{false, false, true, false, true}, GreaterTo(dst, s, u), "GreaterTo")
+	areMasksEqual(t, []bool{false, false, false, true, true}, GreaterConstTo(dst, s, 4), "GreaterConstTo")
+	if !Panics(func() { LessTo(dst, s, u[:4]) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+	if !Panics(func() { GreaterConstTo(dst[:4], s, 1) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestMaskBitset(t *testing.T) {
+	s := make([]int, 150)
+	for i := range s {
+		s[i] = i*7%5 - 2
+	}
+	mask := GreaterConstTo(make([]bool, len(s)), s, 0)
+	b := MaskToBitset(make([]uint64, 3), mask)
+	inds, _ := Find(nil, func(v int) bool { return v > 0 }, s, -1)
+	AreSlicesEqual(t, inds, FromBitset(nil, b), "Packed mask")
+	areMasksEqual(t, mask, BitsetToMask(make([]bool, len(s)), b), "Unpacked mask")
+	if !Panics(func() { MaskToBitset(make([]uint64, 2), mask) }) {
+		t.Errorf("Did not panic with short bitset")
+	}
+	if !Panics(func() { BitsetToMask(make([]bool, 64), make([]uint64, 2)) }) {
+		t.Errorf("Did not panic with long bitset")
+	}
+}
+
+func TestAnyAll(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	positive := func(v int) bool { return v > 0 }
+	big := func(v int) bool { return v > 6 }
+	if !Any(big, s) || Any(func(v int) bool { return v > 7 }, s) || Any(positive, nil) {
+		t.Errorf("Wrong result from Any")
+	}
+	if !All(positive, s) || All(big, s) || !All(big, nil) {
+		t.Errorf("Wrong result from All")
+	}
+}
+
+func TestWhere(t *testing.T) {
+	a := []int{3, 4, 1, 7, 5}
+	b := []int{0, 9, 0, 2, 2}
+	mask := GreaterTo(make([]bool, len(a)), a, b)
+	AreSlicesEqual(t, []int{3, 9, 1, 7, 5}, Where(make([]int, len(a)), mask, a, b), "Where")
+	AreSlicesEqual(t, []int{3, 9, 1, 7, 5}, Where(b, mask, a, b), "Where in place")
+	if !Panics(func() { Where(make([]int, 5), mask[:4], a, b) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
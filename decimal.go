@@ -0,0 +1,418 @@
+package ints
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// MaxDecimalScale is the largest scale of a Decimal. It is chosen so that
+// 10^MaxDecimalScale, the number of units in 1, fits in an int64.
+const MaxDecimalScale = 18
+
+// pow10 holds the powers of ten that fit in a uint64.
+var pow10 = [20]uint64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+}
+
+// Decimal is a vector of fixed-point decimal numbers. Element i of the
+// vector is Data[i] * 10^-Scale, so that a Scale of 4 stores amounts in
+// ten-thousandths. Arithmetic between vectors of different scales rescales
+// the operands exactly and rounds the result to the scale of the
+// destination, to the nearest unit with ties to even.
+type Decimal struct {
+	Data  []int
+	Scale int
+}
+
+// NewDecimal returns a Decimal of the values in data at the given scale.
+// NewDecimal panics if scale is negative or greater than MaxDecimalScale.
+func NewDecimal(data []int, scale int) Decimal {
+	d := Decimal{Data: data, Scale: scale}
+	d.check()
+	return d
+}
+
+func (d Decimal) check() {
+	checkScale(d.Scale)
+}
+
+func checkScale(scale int) {
+	if scale < 0 || scale > MaxDecimalScale {
+		panic(invalidArgument("decimal scale out of range"))
+	}
+}
+
+// Len returns the number of elements of d.
+func (d Decimal) Len() int {
+	return len(d.Data)
+}
+
+// Add adds, element-wise, s to d. If d and s have different scales, s is
+// rescaled to the scale of d. ErrOverflow is returned, wrapped with the
+// index of the element, if a result does not fit in an int; the elements
+// of d before it hold their sums.
+// It panics if the lengths of d and s do not match.
+func (d Decimal) Add(s Decimal) error {
+	return d.addScaled("Decimal.Add", s, false)
+}
+
+// Sub subtracts, element-wise, s from d. If d and s have different scales,
+// s is rescaled to the scale of d. ErrOverflow is returned, wrapped with
+// the index of the element, if a result does not fit in an int; the
+// elements of d before it hold their differences.
+// It panics if the lengths of d and s do not match.
+func (d Decimal) Sub(s Decimal) error {
+	return d.addScaled("Decimal.Sub", s, true)
+}
+
+func (d Decimal) addScaled(fn string, s Decimal, sub bool) error {
+	d.check()
+	s.check()
+	if len(d.Data) != len(s.Data) {
+		panic(lengthError(fn, "d, s", len(d.Data), len(s.Data)))
+	}
+	for i, val := range s.Data {
+		mag, neg := magnitude(val)
+		v, ok := rescale(0, mag, neg != sub, s.Scale-d.Scale)
+		if ok {
+			d.Data[i], ok = addChecked(d.Data[i], v)
+		}
+		if !ok {
+			return fmt.Errorf("%w: element %d", ErrOverflow, i)
+		}
+	}
+	return nil
+}
+
+// MulTo performs element-wise multiplication of a and b and stores the
+// result, rounded to the scale of dst, in dst. The product is exact before
+// it is rounded. ErrOverflow is returned, wrapped with the index of the
+// element, if a result does not fit in an int; the elements of dst before
+// it hold their products. dst may be the same as a or b.
+// It panics if the lengths of dst, a and b do not match.
+func (dst Decimal) MulTo(a, b Decimal) error {
+	dst.check()
+	a.check()
+	b.check()
+	if len(dst.Data) != len(a.Data) || len(dst.Data) != len(b.Data) {
+		panic(lengthError("Decimal.MulTo", "dst, a, b", len(dst.Data), len(a.Data), len(b.Data)))
+	}
+	for i, val := range a.Data {
+		amag, aneg := magnitude(val)
+		bmag, bneg := magnitude(b.Data[i])
+		hi, lo := bits.Mul64(amag, bmag)
+		v, ok := rescale(hi, lo, aneg != bneg, a.Scale+b.Scale-dst.Scale)
+		if !ok {
+			return fmt.Errorf("%w: element %d", ErrOverflow, i)
+		}
+		dst.Data[i] = v
+	}
+	return nil
+}
+
+// DivTo performs element-wise division of a by b and stores the result,
+// rounded to the scale of dst, in dst. The quotient is exact before it is
+// rounded. ErrOverflow is returned, wrapped with the index of the element,
+// if a result does not fit in an int; the elements of dst before it hold
+// their quotients. dst may be the same as a or b.
+// It panics if the lengths of dst, a and b do not match or, as with DivTo,
+// if an element of b is zero.
+func (dst Decimal) DivTo(a, b Decimal) error {
+	dst.check()
+	a.check()
+	b.check()
+	if len(dst.Data) != len(a.Data) || len(dst.Data) != len(b.Data) {
+		panic(lengthError("Decimal.DivTo", "dst, a, b", len(dst.Data), len(a.Data), len(b.Data)))
+	}
+	// The quotient in units of dst is a * 10^j / b.
+	j := dst.Scale - a.Scale + b.Scale
+	for i, val := range a.Data {
+		if b.Data[i] == 0 {
+			panic(invalidArgument("division by zero"))
+		}
+		amag, aneg := magnitude(val)
+		bmag, bneg := magnitude(b.Data[i])
+		v, ok := divPow10(amag, bmag, j, aneg != bneg)
+		if !ok {
+			return fmt.Errorf("%w: element %d", ErrOverflow, i)
+		}
+		dst.Data[i] = v
+	}
+	return nil
+}
+
+// Sum returns the sum of the elements of d, in units of 10^-d.Scale, or
+// ErrOverflow if the sum does not fit in an int.
+func (d Decimal) Sum() (int, error) {
+	d.check()
+	var sum int
+	for _, val := range d.Data {
+		var ok bool
+		sum, ok = addChecked(sum, val)
+		if !ok {
+			return 0, ErrOverflow
+		}
+	}
+	return sum, nil
+}
+
+// String returns the elements of d as decimal strings, separated by
+// spaces.
+func (d Decimal) String() string {
+	d.check()
+	var b []byte
+	for i, val := range d.Data {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		b = AppendDecimal(b, val, d.Scale)
+	}
+	return string(b)
+}
+
+// ParseDecimals parses the decimal strings in s, separated by white
+// space, at the given scale and appends them to dst[:0]. Errors are as
+// for ParseDecimal, with the line and column of the offending value.
+func ParseDecimals(dst []int, s string, scale int) (Decimal, error) {
+	d := NewDecimal(dst[:0], scale)
+	line, lineStart := 1, 0
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '\n':
+			line++
+			lineStart = i + 1
+			fallthrough
+		case ' ', '\t', '\r', '\v', '\f':
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && !isSpace(s[i]) {
+			i++
+		}
+		v, err := ParseDecimal(s[start:i], scale)
+		if err != nil {
+			perr := err.(*ParseError)
+			perr.Line, perr.Column = line, start-lineStart+1
+			return d, perr
+		}
+		d.Data = append(d.Data, v)
+	}
+	return d, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\v' || c == '\f'
+}
+
+// ParseDecimal parses a decimal string such as "-12.5" and returns its
+// value in units of 10^-scale. The string may have a sign, and may omit
+// either the integer or the fractional digits, but not both. Fractional
+// digits beyond the scale are only accepted if they are zero, so that no
+// value is rounded. Errors are of type *ParseError, with Err set to
+// strconv.ErrSyntax, strconv.ErrRange or, for excess fractional digits, an
+// error wrapping ErrInvalidArgument.
+// ParseDecimal panics if scale is negative or greater than MaxDecimalScale.
+func ParseDecimal(s string, scale int) (int, error) {
+	checkScale(scale)
+	fail := func(err error) (int, error) {
+		return 0, &ParseError{Line: 1, Column: 1, Text: s, Err: err}
+	}
+	body := s
+	neg := false
+	if len(body) > 0 && (body[0] == '+' || body[0] == '-') {
+		neg = body[0] == '-'
+		body = body[1:]
+	}
+	var (
+		mag     uint64
+		digits  int
+		frac    = -1 // fractional digits read, or -1 before the point
+		inRange = true
+	)
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '.' && frac < 0:
+			frac = 0
+		case c >= '0' && c <= '9':
+			digits++
+			if frac >= 0 {
+				if frac == scale {
+					if c != '0' {
+						return fail(invalidArgument(fmt.Sprintf("more than %d fractional digits", scale)))
+					}
+					continue
+				}
+				frac++
+			}
+			d := uint64(c - '0')
+			if mag > (intLimit-d)/10 {
+				inRange = false
+			}
+			mag = mag*10 + d
+		default:
+			return fail(strconv.ErrSyntax)
+		}
+	}
+	if digits == 0 {
+		return fail(strconv.ErrSyntax)
+	}
+	v, ok := rescale(0, mag, neg, -(scale - max(frac, 0)))
+	if !inRange || !ok {
+		return fail(strconv.ErrRange)
+	}
+	return v, nil
+}
+
+// FormatDecimal returns the decimal string of v * 10^-scale, with exactly
+// scale fractional digits.
+// FormatDecimal panics if scale is negative or greater than
+// MaxDecimalScale.
+func FormatDecimal(v, scale int) string {
+	return string(AppendDecimal(nil, v, scale))
+}
+
+// AppendDecimal appends the decimal string of v * 10^-scale, as generated
+// by FormatDecimal, to dst and returns the extended buffer.
+func AppendDecimal(dst []byte, v, scale int) []byte {
+	checkScale(scale)
+	mag, neg := magnitude(v)
+	if neg {
+		dst = append(dst, '-')
+	}
+	var buf [24]byte
+	digits := strconv.AppendUint(buf[:0], mag, 10)
+	// point is the number of digits before the decimal point, which is
+	// not positive when the value is below 1.
+	point := len(digits) - scale
+	if point <= 0 {
+		dst = append(dst, '0')
+	} else {
+		dst = append(dst, digits[:point]...)
+	}
+	if scale > 0 {
+		dst = append(dst, '.')
+		for ; point < 0; point++ {
+			dst = append(dst, '0')
+		}
+		dst = append(dst, digits[point:]...)
+	}
+	return dst
+}
+
+// magnitude returns the absolute value of v as a uint64, which holds the
+// magnitude of the minimum int, and whether v is negative.
+func magnitude(v int) (uint64, bool) {
+	if v < 0 {
+		return -uint64(v), true
+	}
+	return uint64(v), false
+}
+
+// toInt returns the int of magnitude mag, negated if neg is set, and
+// whether it is in range.
+func toInt(mag uint64, neg bool) (int, bool) {
+	if mag > intLimit || (mag == intLimit && !neg) {
+		return 0, false
+	}
+	if neg {
+		return int(-mag), true
+	}
+	return int(mag), true
+}
+
+// rescale returns the 128-bit magnitude hi, lo scaled by 10^-k, rounded to
+// the nearest integer with ties to even, negated if neg is set, and
+// whether the result fits in an int.
+func rescale(hi, lo uint64, neg bool, k int) (int, bool) {
+	if hi == 0 && lo == 0 {
+		return 0, true
+	}
+	if k <= 0 {
+		if hi != 0 {
+			return 0, false
+		}
+		for ; k < 0; k += 19 {
+			hi, lo = bits.Mul64(lo, pow10[min(-k, 19)])
+			if hi != 0 {
+				return 0, false
+			}
+		}
+		return toInt(lo, neg)
+	}
+	// Divide by all but the last power of ten, remembering whether a
+	// remainder was dropped, then round on the last digit.
+	sticky := false
+	for k > 1 {
+		n := min(k-1, 19)
+		var r uint64
+		hi, lo, r = div128(hi, lo, pow10[n])
+		sticky = sticky || r != 0
+		k -= n
+	}
+	hi, lo, r := div128(hi, lo, 10)
+	if r > 5 || (r == 5 && (sticky || lo&1 != 0)) {
+		var c uint64
+		lo, c = bits.Add64(lo, 1, 0)
+		hi += c
+	}
+	if hi != 0 {
+		return 0, false
+	}
+	return toInt(lo, neg)
+}
+
+// div128 returns the 128-bit quotient of hi, lo by d and the remainder.
+func div128(hi, lo, d uint64) (qhi, qlo, r uint64) {
+	qhi, r = bits.Div64(0, hi, d)
+	qlo, r = bits.Div64(r, lo, d)
+	return qhi, qlo, r
+}
+
+// divPow10 returns a * 10^j / b rounded to the nearest integer with ties
+// to even, negated if neg is set, and whether the result fits in an int.
+// j is at least -MaxDecimalScale and b is not zero.
+func divPow10(a, b uint64, j int, neg bool) (int, bool) {
+	// Form the 128-bit numerator n and denominator d.
+	var nhi, nlo, dhi, dlo uint64 = 0, a, 0, b
+	if j < 0 {
+		dhi, dlo = bits.Mul64(b, pow10[-j])
+	}
+	for ; j > 0; j -= 19 {
+		p := pow10[min(j, 19)]
+		hi, lo := bits.Mul64(nlo, p)
+		c1, plo := bits.Mul64(nhi, p)
+		sum, c2 := bits.Add64(hi, plo, 0)
+		if c1 != 0 || c2 != 0 {
+			// The numerator is at least 2^128 and the denominator below
+			// 2^64, so the quotient cannot fit.
+			return 0, false
+		}
+		nhi, nlo = sum, lo
+	}
+	if dhi != 0 {
+		// The numerator is a, so the quotient is below 1. It rounds to 1
+		// if twice the numerator exceeds the denominator.
+		thi, tlo := a>>63, a<<1
+		if thi > dhi || (thi == dhi && tlo > dlo) {
+			return toInt(1, neg)
+		}
+		return 0, true
+	}
+	if nhi >= dlo {
+		return 0, false
+	}
+	q, r := bits.Div64(nhi, nlo, dlo)
+	// Round up if the remainder is above half the divisor, or equal to it
+	// and the quotient is odd.
+	if r > dlo-r || (r == dlo-r && q&1 != 0) {
+		q++
+		if q == 0 {
+			return 0, false
+		}
+	}
+	return toInt(q, neg)
+}
@@ -0,0 +1,204 @@
+package ints
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// bigDecimal returns v * 10^-scale as an exact rational.
+func bigDecimal(v, scale int) *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(int64(v)), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+}
+
+// roundDecimal rounds x to the given scale with ties to even and reports
+// whether the result fits in an int.
+func roundDecimal(x *big.Rat, scale int) (int, bool) {
+	x = new(big.Rat).Mul(x, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)))
+	num, den := x.Num(), x.Denom()
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	// Compare twice the remainder with the denominator.
+	switch c := new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(den); {
+	case c > 0 || c == 0 && q.Bit(0) == 1:
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() {
+		return 0, false
+	}
+	return int(q.Int64()), true
+}
+
+func TestDecimalAddSub(t *testing.T) {
+	d := NewDecimal([]int{12500, -100, 0}, 4)
+	if err := d.Add(NewDecimal([]int{25, 1, -7}, 2)); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, []int{15000, 0, -700}, d.Data, "Add")
+	// 0.00005 and 0.00015 round to even at a scale of 4.
+	if err := d.Sub(NewDecimal([]int{5, 15, -5}, 5)); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, []int{15000, -2, -700}, d.Data, "Sub")
+
+	d = NewDecimal([]int{math.MaxInt - 1, 0}, 0)
+	if err := d.Add(NewDecimal([]int{1, 2}, 0)); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if err := d.Add(NewDecimal([]int{1, 0}, 0)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Add beyond MaxInt returned %v", err)
+	}
+	if err := NewDecimal([]int{0}, 3).Add(NewDecimal([]int{math.MaxInt / 100}, 0)); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Rescaling beyond MaxInt returned %v", err)
+	}
+	if !Panics(func() { d.Add(NewDecimal([]int{1}, 0)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+	if !Panics(func() { NewDecimal(nil, MaxDecimalScale+1) }) {
+		t.Errorf("Did not panic with scale out of range")
+	}
+}
+
+func TestDecimalMulDiv(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	vals := []int{0, 1, -1, 5, 15, 25, 10000, math.MaxInt, math.MinInt, math.MinInt + 1}
+	for i := 0; i < 30; i++ {
+		vals = append(vals, rnd.Int()>>rnd.Intn(64)*(1-2*rnd.Intn(2)))
+	}
+	scales := []int{0, 1, 2, 4, 9, 18}
+	for n := 0; n < 2000; n++ {
+		av, bv := vals[rnd.Intn(len(vals))], vals[rnd.Intn(len(vals))]
+		as, bs, ds := scales[rnd.Intn(len(scales))], scales[rnd.Intn(len(scales))], scales[rnd.Intn(len(scales))]
+		a, b := NewDecimal([]int{av}, as), NewDecimal([]int{bv}, bs)
+		dst := NewDecimal(make([]int, 1), ds)
+
+		want, ok := roundDecimal(new(big.Rat).Mul(bigDecimal(av, as), bigDecimal(bv, bs)), ds)
+		err := dst.MulTo(a, b)
+		if ok != (err == nil) || ok && dst.Data[0] != want {
+			t.Errorf("%v * %v at scale %v: got %v, %v, want %v", a, b, ds, dst.Data[0], err, want)
+		}
+		if bv == 0 {
+			continue
+		}
+		want, ok = roundDecimal(new(big.Rat).Quo(bigDecimal(av, as), bigDecimal(bv, bs)), ds)
+		err = dst.DivTo(a, b)
+		if ok != (err == nil) || ok && dst.Data[0] != want {
+			t.Errorf("%v / %v at scale %v: got %v, %v, want %v", a, b, ds, dst.Data[0], err, want)
+		}
+	}
+
+	dst := NewDecimal(make([]int, 3), 2)
+	a := NewDecimal([]int{150, 250, -1000}, 2)
+	if err := dst.MulTo(a, NewDecimal([]int{5, 5, 3333}, 1)); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, []int{75, 125, -333300}, dst.Data, "MulTo")
+	if err := dst.DivTo(a, NewDecimal([]int{3, 2, 7}, 0)); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, []int{50, 125, -143}, dst.Data, "DivTo")
+	if !Panics(func() { dst.DivTo(a, NewDecimal([]int{1, 0, 1}, 0)) }) {
+		t.Errorf("Did not panic with zero divisor")
+	}
+	if !Panics(func() { dst.MulTo(a, NewDecimal([]int{1}, 0)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestDecimalSum(t *testing.T) {
+	d := NewDecimal([]int{12500, -100, 7}, 4)
+	if sum, err := d.Sum(); sum != 12407 || err != nil {
+		t.Errorf("Sum = %v, %v", sum, err)
+	}
+	d = NewDecimal([]int{math.MaxInt, 1, -2}, 4)
+	if _, err := d.Sum(); err != ErrOverflow {
+		t.Errorf("Sum beyond MaxInt returned %v", err)
+	}
+	if !Panics(func() { Decimal{Scale: -1}.Sum() }) {
+		t.Errorf("Sum did not panic with scale out of range")
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		scale int
+		v     int
+		err   error
+	}{
+		{"12.5", 2, 1250, nil},
+		{"-0.07", 2, -7, nil},
+		{"+3", 4, 30000, nil},
+		{".5", 1, 5, nil},
+		{"5.", 1, 50, nil},
+		{"1.2500", 2, 125, nil},
+		{"-9223372036854775808", 0, math.MinInt, nil},
+		{"-922337203685477.5808", 4, math.MinInt, nil},
+		{"9223372036854775808", 0, 0, strconv.ErrRange},
+		{"922337203685477.5808", 4, 0, strconv.ErrRange},
+		{"1000000000000000000000", 0, 0, strconv.ErrRange},
+		{"1.25", 1, 0, nil},
+		{"", 2, 0, strconv.ErrSyntax},
+		{".", 2, 0, strconv.ErrSyntax},
+		{"-", 2, 0, strconv.ErrSyntax},
+		{"1.2.3", 2, 0, strconv.ErrSyntax},
+		{"1e3", 2, 0, strconv.ErrSyntax},
+	} {
+		v, err := ParseDecimal(test.s, test.scale)
+		if test.s == "1.25" {
+			var perr *ParseError
+			if !errors.As(err, &perr) || !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("ParseDecimal(%q, %v) returned %v", test.s, test.scale, err)
+			}
+			continue
+		}
+		if !errors.Is(err, test.err) || v != test.v {
+			t.Errorf("ParseDecimal(%q, %v) = %v, %v, want %v, %v", test.s, test.scale, v, err, test.v, test.err)
+		}
+	}
+}
+
+func TestFormatDecimal(t *testing.T) {
+	for _, test := range []struct {
+		v     int
+		scale int
+		s     string
+	}{
+		{1250, 2, "12.50"},
+		{-7, 2, "-0.07"},
+		{0, 3, "0.000"},
+		{42, 0, "42"},
+		{5, 5, "0.00005"},
+		{math.MinInt, 4, "-922337203685477.5808"},
+	} {
+		s := FormatDecimal(test.v, test.scale)
+		if s != test.s {
+			t.Errorf("FormatDecimal(%v, %v) = %q, want %q", test.v, test.scale, s, test.s)
+		}
+		if v, err := ParseDecimal(s, test.scale); v != test.v || err != nil {
+			t.Errorf("ParseDecimal(%q, %v) = %v, %v", s, test.scale, v, err)
+		}
+	}
+}
+
+func TestParseDecimals(t *testing.T) {
+	d, err := ParseDecimals(nil, " 1.5  -2\n0.25\t", 2)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, []int{150, -200, 25}, d.Data, "Parsed decimals")
+	if s := d.String(); s != "1.50 -2.00 0.25" {
+		t.Errorf("String = %q", s)
+	}
+	_, err = ParseDecimals(nil, "1.5 2\n 3.x", 2)
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Line != 2 || perr.Column != 2 || perr.Text != "3.x" {
+		t.Errorf("Parsing bad value returned %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package ints
+
+import "strconv"
+
+// MaxTo stores in dst the element-wise maximum of all the slices. dst may
+// be one of the slices.
+// A panic will occur if no slices are given or if lengths of arguments do
+// not match.
+func MaxTo(dst []int, slices ...[]int) []int {
+	checkSlices("MaxTo", dst, slices)
+	for i := range dst {
+		m := slices[0][i]
+		for _, slice := range slices[1:] {
+			m = max(m, slice[i])
+		}
+		dst[i] = m
+	}
+	return dst
+}
+
+// MinTo stores in dst the element-wise minimum of all the slices. dst may
+// be one of the slices.
+// A panic will occur if no slices are given or if lengths of arguments do
+// not match.
+func MinTo(dst []int, slices ...[]int) []int {
+	checkSlices("MinTo", dst, slices)
+	for i := range dst {
+		m := slices[0][i]
+		for _, slice := range slices[1:] {
+			m = min(m, slice[i])
+		}
+		dst[i] = m
+	}
+	return dst
+}
+
+func checkSlices(fn string, dst []int, slices [][]int) {
+	if len(slices) == 0 {
+		panic(invalidArgument("at least one slice is required"))
+	}
+	for i, slice := range slices {
+		if len(slice) != len(dst) {
+			panic(lengthError(fn, "dst, slices["+strconv.Itoa(i)+"]", len(dst), len(slice)))
+		}
+	}
+}
+
+// Clamp limits every element of dst to the interval [lo, hi].
+// A panic will occur if lo > hi.
+func Clamp(dst []int, lo, hi int) []int {
+	if lo > hi {
+		panic(invalidArgument("lower bound exceeds upper bound"))
+	}
+	for i, val := range dst {
+		dst[i] = min(max(val, lo), hi)
+	}
+	return dst
+}
+
+// Abs stores the absolute value of each element of s in dst. The minimum
+// int has no positive counterpart and is stored unchanged, so it is the
+// only negative value Abs can produce; this matches Neg. dst and s may be
+// the same slice.
+// A panic will occur if lengths of arguments do not match.
+func Abs(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("Abs", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		if val < 0 {
+			val = -val
+		}
+		dst[i] = val
+	}
+	return dst
+}
+
+// Neg stores the negation of each element of s in dst. As with the unary
+// minus operator, the minimum int is stored unchanged. dst and s may be the
+// same slice.
+// A panic will occur if lengths of arguments do not match.
+func Neg(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("Neg", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = -val
+	}
+	return dst
+}
+
+// Sign stores in dst -1, 0 or 1 according to whether each element of s is
+// negative, zero or positive. dst and s may be the same slice.
+// A panic will occur if lengths of arguments do not match.
+func Sign(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("Sign", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		switch {
+		case val < 0:
+			dst[i] = -1
+		case val > 0:
+			dst[i] = 1
+		default:
+			dst[i] = 0
+		}
+	}
+	return dst
+}
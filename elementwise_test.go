@@ -0,0 +1,47 @@
+package ints
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMaxMinTo(t *testing.T) {
+	a := []int{3, 4, 1, 7, 5}
+	b := []int{2, 9, 1, 3, 8}
+	c := []int{4, -1, 0, 3, 6}
+	dst := make([]int, len(a))
+	AreSlicesEqual(t, []int{4, 9, 1, 7, 8}, MaxTo(dst, a, b, c), "MaxTo")
+	AreSlicesEqual(t, []int{2, -1, 0, 3, 5}, MinTo(dst, a, b, c), "MinTo")
+	AreSlicesEqual(t, a, MaxTo(dst, a), "MaxTo single slice")
+	AreSlicesEqual(t, []int{2, 4, 1, 3, 5}, MinTo(a, b, a), "MinTo in place")
+
+	err := recovered(func() { MaxTo(dst, a, b[:4]) })
+	var lerr *LengthError
+	if !errors.As(err, &lerr) || lerr.Args[1] != "slices[1]" {
+		t.Errorf("MaxTo with length mismatch panicked with %v", err)
+	}
+	if !errors.Is(recovered(func() { MinTo(dst) }), ErrInvalidArgument) {
+		t.Errorf("MinTo without slices did not panic with ErrInvalidArgument")
+	}
+}
+
+func TestClamp(t *testing.T) {
+	s := []int{3, -4, 1, 7, 5}
+	AreSlicesEqual(t, []int{3, 0, 1, 5, 5}, Clamp(s, 0, 5), "Clamp")
+	if !Panics(func() { Clamp(s, 5, 0) }) {
+		t.Errorf("Did not panic with inverted bounds")
+	}
+}
+
+func TestAbsNegSign(t *testing.T) {
+	s := []int{3, -4, 0, math.MaxInt, math.MinInt}
+	dst := make([]int, len(s))
+	AreSlicesEqual(t, []int{3, 4, 0, math.MaxInt, math.MinInt}, Abs(dst, s), "Abs")
+	AreSlicesEqual(t, []int{-3, 4, 0, -math.MaxInt, math.MinInt}, Neg(dst, s), "Neg")
+	AreSlicesEqual(t, []int{1, -1, 0, 1, -1}, Sign(dst, s), "Sign")
+	AreSlicesEqual(t, []int{1, -1, 0, 1, -1}, Sign(s, s), "Sign in place")
+	if !Panics(func() { Abs(dst[:4], s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
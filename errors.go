@@ -0,0 +1,78 @@
+package ints
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The functions of this package panic with the error values below when
+// called with invalid arguments, and return them, possibly wrapped with
+// further detail, when an operation fails. A recovered panic value can be
+// inspected with errors.Is and errors.As.
+var (
+	// ErrEmpty is used when a function requires a non-empty slice.
+	ErrEmpty = errors.New("ints: zero length slice")
+	// ErrInsufficientElements is returned by Find when fewer elements
+	// than requested satisfy the predicate.
+	ErrInsufficientElements = errors.New("ints: insufficient elements found")
+	// ErrIndexOutOfRange is used when an index lies outside a slice,
+	// vector, matrix or block.
+	ErrIndexOutOfRange = errors.New("ints: index out of range")
+	// ErrNotPermutation is used when a slice required to be a permutation
+	// is not one.
+	ErrNotPermutation = errors.New("ints: not a permutation")
+	// ErrInvalidArgument is wrapped by errors describing an argument
+	// outside the domain of a function.
+	ErrInvalidArgument = errors.New("ints: invalid argument")
+	// ErrOverflow is used when an exact integer result does not fit in
+	// the type holding it.
+	ErrOverflow = errors.New("ints: integer overflow")
+	// ErrMalformed is wrapped by errors describing malformed encoded
+	// input.
+	ErrMalformed = errors.New("ints: malformed input")
+	// ErrEmptyField is reported in a *ParseError when a separator is not
+	// surrounded by values.
+	ErrEmptyField = errors.New("ints: empty field")
+)
+
+// LengthError is used when the lengths of the arguments to a function do
+// not match.
+type LengthError struct {
+	Func string   // the function reporting the error
+	Args []string // the names of the arguments
+	Lens []int    // the lengths of the arguments, in the order of Args
+}
+
+func (e *LengthError) Error() string {
+	var b strings.Builder
+	b.WriteString("ints: ")
+	b.WriteString(e.Func)
+	b.WriteString(": mismatched lengths")
+	for i, arg := range e.Args {
+		b.WriteString(" ")
+		b.WriteString(arg)
+		b.WriteString("=")
+		if i < len(e.Lens) {
+			b.WriteString(strconv.Itoa(e.Lens[i]))
+		}
+	}
+	return b.String()
+}
+
+// lengthError returns a *LengthError for fn. args holds the comma-separated
+// names of the arguments whose lengths are given by lens.
+func lengthError(fn, args string, lens ...int) *LengthError {
+	return &LengthError{Func: fn, Args: strings.Split(args, ", "), Lens: lens}
+}
+
+// invalidArgument returns an error wrapping ErrInvalidArgument.
+func invalidArgument(msg string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidArgument, msg)
+}
+
+// malformed returns an error wrapping ErrMalformed.
+func malformed(msg string) error {
+	return fmt.Errorf("%w: %s", ErrMalformed, msg)
+}
@@ -0,0 +1,79 @@
+package ints
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// recovered returns the value passed to panic by fun.
+func recovered(fun func()) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+	fun()
+	return nil
+}
+
+func TestLengthError(t *testing.T) {
+	err := recovered(func() { AddScaledTo(make([]int, 3), make([]int, 3), 2, make([]int, 1)) })
+	var lerr *LengthError
+	if !errors.As(err, &lerr) {
+		t.Fatalf("Panic value %v is not a *LengthError", err)
+	}
+	if lerr.Func != "AddScaledTo" || strings.Join(lerr.Args, ",") != "dst,y,s" || !Equal(lerr.Lens, []int{3, 3, 1}) {
+		t.Errorf("Wrong error contents %+v", lerr)
+	}
+	if got := lerr.Error(); got != "ints: AddScaledTo: mismatched lengths dst=3 y=3 s=1" {
+		t.Errorf("Wrong message %q", got)
+	}
+
+	for name, fun := range map[string]func(){
+		"Add":       func() { Add(make([]int, 2), make([]int, 3)) },
+		"Argsort":   func() { Argsort(make([]int, 2), make([]int, 3)) },
+		"CumSum":    func() { CumSum(make([]int, 2), make([]int, 3)) },
+		"Dot":       func() { Dot(make([]int, 2), make([]int, 3)) },
+		"SubTo":     func() { SubTo(make([]int, 3), make([]int, 3), make([]int, 2)) },
+		"MatVec":    func() { MatVec(make([]int, 2), NewMatrix(2, 2, nil), make([]int, 3)) },
+		"PairsSeq":  func() { PairsSeq(make([]int, 2), make([]int, 3)) },
+		"PopCount":  func() { PopCount(make([]int, 2), make([]int, 3)) },
+		"Transpose": func() { Transpose(NewMatrix(2, 2, nil), NewMatrix(2, 3, nil)) },
+	} {
+		if !errors.As(recovered(fun), &lerr) || lerr.Func != name {
+			t.Errorf("%v did not panic with a *LengthError", name)
+		}
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		fun  func()
+		err  error
+	}{
+		{"Max", func() { Max(nil) }, ErrEmpty},
+		{"Min", func() { Min([]int{}) }, ErrEmpty},
+		{"CumSum", func() { CumSum(nil, nil) }, ErrEmpty},
+		{"CumProd", func() { CumProd(nil, nil) }, ErrEmpty},
+		{"Span", func() { Span(make([]int, 1), 1, 5) }, ErrInvalidArgument},
+		{"Matrix.At", func() { NewMatrix(2, 2, nil).At(2, 0) }, ErrIndexOutOfRange},
+		{"ToBitset", func() { ToBitset(make([]uint64, 1), []int{64}) }, ErrIndexOutOfRange},
+		{"InvertPermutation", func() { InvertPermutation(make([]int, 2), []int{1, 1}) }, ErrNotPermutation},
+		{"Intn", func() { Intn(NewSplitMix(1), 0) }, ErrInvalidArgument},
+		{"NewAlias", func() { NewAlias([]int{-1}) }, ErrInvalidArgument},
+	} {
+		if err := recovered(test.fun); !errors.Is(err, test.err) {
+			t.Errorf("%v panicked with %v, want %v", test.name, err, test.err)
+		}
+	}
+
+	if _, err := Find(nil, func(v int) bool { return v > 3 }, []int{3, 4}, 2); err != ErrInsufficientElements {
+		t.Errorf("Find returned %v", err)
+	}
+	if _, err := DecodeInto(nil, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("DecodeInto returned %v", err)
+	}
+	if err := WriteNpy(new(strings.Builder), []int{300}, NpyHeader{Dtype: "|u1"}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("WriteNpy returned %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package ints
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Rounding selects how a float64 is rounded to an integer.
+type Rounding int
+
+const (
+	// RoundTruncate rounds toward zero.
+	RoundTruncate Rounding = iota
+	// RoundHalfEven rounds to the nearest integer, and to the even one of
+	// the two nearest integers on a tie.
+	RoundHalfEven
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+)
+
+// round rounds v to an integral float64 under mode.
+func (mode Rounding) round(v float64) float64 {
+	switch mode {
+	case RoundTruncate:
+		return math.Trunc(v)
+	case RoundHalfEven:
+		return math.RoundToEven(v)
+	case RoundFloor:
+		return math.Floor(v)
+	case RoundCeil:
+		return math.Ceil(v)
+	}
+	panic(invalidArgument("unknown rounding mode"))
+}
+
+// intLimit is 2^(n-1) for an n-bit int, the magnitude of the minimum int.
+const intLimit = 1 << (bits.UintSize - 1)
+
+// FromFloat64 rounds each element of src under mode and stores the result
+// in dst, so that slices from gonum/floats can be brought into this
+// package. If an element is NaN or rounds to a value outside the range of
+// int, FromFloat64 stops and returns an error wrapping ErrInvalidArgument
+// or ErrOverflow respectively; the elements of dst before the failing one
+// hold their converted values.
+// A panic will occur if lengths of arguments do not match.
+func FromFloat64(dst []int, src []float64, mode Rounding) ([]int, error) {
+	if len(dst) != len(src) {
+		panic(lengthError("FromFloat64", "dst, src", len(dst), len(src)))
+	}
+	for i, val := range src {
+		if math.IsNaN(val) {
+			return dst, invalidArgument(fmt.Sprintf("element %d is NaN", i))
+		}
+		r := mode.round(val)
+		if !(r >= -intLimit && r < intLimit) {
+			return dst, fmt.Errorf("%w: element %d (%g) does not fit in int", ErrOverflow, i, val)
+		}
+		dst[i] = int(r)
+	}
+	return dst, nil
+}
+
+// ToFloat64 converts each element of s to float64 and stores the result in
+// dst. Values of magnitude above 2^53 are rounded to the nearest float64,
+// with ties to even.
+// A panic will occur if lengths of arguments do not match.
+func ToFloat64(dst []float64, s []int) []float64 {
+	if len(dst) != len(s) {
+		panic(lengthError("ToFloat64", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = float64(val)
+	}
+	return dst
+}
+
+// ScaleFloat multiplies every element of s by alpha and stores the result,
+// rounded to the nearest integer with ties to even, in dst. The product is
+// computed exactly before it is rounded, so unlike converting to float64,
+// multiplying and converting back, the result is correct for all values
+// of s. If a result does not fit in an int, ScaleFloat stops and returns
+// an error wrapping ErrOverflow; the elements of dst before the failing
+// one hold their scaled values. dst and s may be the same slice.
+// A panic will occur if lengths of arguments do not match or if alpha is
+// not finite.
+func ScaleFloat(dst []int, alpha float64, s []int) ([]int, error) {
+	if len(dst) != len(s) {
+		panic(lengthError("ScaleFloat", "dst, s", len(dst), len(s)))
+	}
+	if math.IsNaN(alpha) || math.IsInf(alpha, 0) {
+		panic(invalidArgument("scale factor is not finite"))
+	}
+	// Write alpha as m * 2^e with m an integer of at most 53 bits.
+	frac, exp := math.Frexp(alpha)
+	m := int64(frac * (1 << 53))
+	e := exp - 53
+	mneg := m < 0
+	if mneg {
+		m = -m
+	}
+	for i, val := range s {
+		mag := uint64(val)
+		neg := val < 0
+		if neg {
+			mag = -mag
+		}
+		neg = neg != mneg
+		r, ok := scaleExact(mag, uint64(m), e, neg)
+		if !ok {
+			return dst, fmt.Errorf("%w: element %d scaled by %g does not fit in int", ErrOverflow, i, alpha)
+		}
+		dst[i] = r
+	}
+	return dst, nil
+}
+
+// scaleExact returns x * m * 2^e rounded to the nearest integer with ties
+// to even, negated if neg is set, and whether the result fits in an int.
+func scaleExact(x, m uint64, e int, neg bool) (int, bool) {
+	hi, lo := bits.Mul64(x, m)
+	if hi == 0 && lo == 0 {
+		return 0, true
+	}
+	var q uint64
+	switch {
+	case e >= 0:
+		if e >= 64 || hi != 0 || lo>>(63-e) > 1 {
+			return 0, false
+		}
+		q = lo << e
+	case e <= -128:
+		// The product has at most 117 bits, so it is below one half.
+		return 0, true
+	default:
+		k := uint(-e)
+		// Split the product into the quotient q, the bit below it and
+		// whether any lower bit is set.
+		var half, sticky bool
+		if k >= 64 {
+			q = hi >> (k - 64)
+			if k == 64 {
+				half = lo>>63 != 0
+				sticky = lo<<1 != 0
+			} else {
+				half = hi>>(k-65)&1 != 0
+				sticky = lo != 0 || hi<<(128-k+1) != 0
+			}
+		} else {
+			if hi>>k != 0 {
+				return 0, false
+			}
+			q = lo>>k | hi<<(64-k)
+			half = lo>>(k-1)&1 != 0
+			sticky = lo<<(64-k+1) != 0
+		}
+		if half && (sticky || q&1 != 0) {
+			q++
+			if q == 0 {
+				return 0, false
+			}
+		}
+	}
+	if q > intLimit || (q == intLimit && !neg) {
+		return 0, false
+	}
+	if neg {
+		return int(-q), true
+	}
+	return int(q), true
+}
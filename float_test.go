@@ -0,0 +1,128 @@
+package ints
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestFromFloat64(t *testing.T) {
+	src := []float64{-2.5, -1.5, -0.5, 0.5, 1.5, 2.5, 2.7, -2.7}
+	for _, test := range []struct {
+		mode  Rounding
+		truth []int
+	}{
+		{RoundTruncate, []int{-2, -1, 0, 0, 1, 2, 2, -2}},
+		{RoundHalfEven, []int{-2, -2, 0, 0, 2, 2, 3, -3}},
+		{RoundFloor, []int{-3, -2, -1, 0, 1, 2, 2, -3}},
+		{RoundCeil, []int{-2, -1, 0, 1, 2, 3, 3, -2}},
+	} {
+		dst, err := FromFloat64(make([]int, len(src)), src, test.mode)
+		if err != nil {
+			t.Errorf("Mode %v: unexpected error %v", test.mode, err)
+		}
+		AreSlicesEqual(t, test.truth, dst, "Rounded values")
+	}
+
+	dst, err := FromFloat64(make([]int, 2), []float64{-0x1p63, 0x1p62}, RoundTruncate)
+	if err != nil {
+		t.Errorf("Unexpected error at the limits of int: %v", err)
+	}
+	AreSlicesEqual(t, []int{math.MinInt, 1 << 62}, dst, "Limits of int")
+	for _, v := range []float64{0x1p63, -0x1p63 - 2048, math.Inf(1), math.Inf(-1)} {
+		if _, err := FromFloat64(make([]int, 1), []float64{v}, RoundHalfEven); !errors.Is(err, ErrOverflow) {
+			t.Errorf("Converting %v returned %v", v, err)
+		}
+	}
+	if _, err := FromFloat64(make([]int, 2), []float64{1, math.NaN()}, RoundFloor); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Converting NaN returned %v", err)
+	}
+	if !Panics(func() { FromFloat64(make([]int, 1), src, RoundFloor) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestToFloat64(t *testing.T) {
+	s := []int{3, -4, 0, 1 << 53, math.MinInt}
+	dst := ToFloat64(make([]float64, len(s)), s)
+	for i, val := range s {
+		if dst[i] != float64(val) {
+			t.Errorf("Element %v: got %v, want %v", i, dst[i], float64(val))
+		}
+	}
+	back, err := FromFloat64(make([]int, len(s)), dst, RoundTruncate)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, s, back, "Round trip")
+}
+
+// scaleFloatBig computes ScaleFloat for a single value with math/big.
+func scaleFloatBig(alpha float64, v int) (int, bool) {
+	// The exact product has at most 117 bits.
+	p := new(big.Float).SetPrec(200).SetFloat64(alpha)
+	p.Mul(p, new(big.Float).SetInt64(int64(v)))
+	// Round half to even by hand, since big.Float.Int truncates.
+	q, _ := p.Int(nil)
+	rem := new(big.Float).SetPrec(200).Sub(p, new(big.Float).SetInt(q))
+	switch c := rem.Abs(rem).Cmp(big.NewFloat(0.5)); {
+	case c > 0 || c == 0 && q.Bit(0) == 1:
+		if p.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if !q.IsInt64() {
+		return 0, false
+	}
+	return int(q.Int64()), true
+}
+
+func TestScaleFloat(t *testing.T) {
+	s := []int{3, -4, 1, 7, 5}
+	dst, err := ScaleFloat(make([]int, len(s)), 0.5, s)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, []int{2, -2, 0, 4, 2}, dst, "Scaled by one half")
+
+	// Converting through float64 loses the low bits of large values.
+	v := 1<<62 + 1
+	dst, _ = ScaleFloat(make([]int, 1), 1.5, []int{v})
+	if want := 3<<61 + 2; dst[0] != want {
+		t.Errorf("Scaling %v by 1.5: got %v, want %v", v, dst[0], want)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	alphas := []float64{0, 1, -1, 0.1, -3.75, 1e-300, 5e-324, 1e10, 0x1p-70, 0x1p-64, 0x1p-63, 0x1p62, -0x1p63, 2.5}
+	vals := []int{0, 1, -1, 3, 1 << 52, math.MaxInt, math.MinInt, math.MinInt + 1}
+	for i := 0; i < 50; i++ {
+		alphas = append(alphas, rnd.NormFloat64()*math.Pow(2, float64(rnd.Intn(140)-70)))
+		vals = append(vals, rnd.Int()>>rnd.Intn(64)*(1-2*rnd.Intn(2)))
+	}
+	for _, alpha := range alphas {
+		for _, val := range vals {
+			want, ok := scaleFloatBig(alpha, val)
+			got, err := ScaleFloat(make([]int, 1), alpha, []int{val})
+			if !ok {
+				if !errors.Is(err, ErrOverflow) {
+					t.Errorf("Scaling %v by %v: expected overflow, got %v, %v", val, alpha, got[0], err)
+				}
+				continue
+			}
+			if err != nil || got[0] != want {
+				t.Errorf("Scaling %v by %v: got %v, %v, want %v", val, alpha, got[0], err, want)
+			}
+		}
+	}
+
+	if !Panics(func() { ScaleFloat(make([]int, 1), math.NaN(), []int{1}) }) {
+		t.Errorf("Did not panic with NaN scale")
+	}
+	if !Panics(func() { ScaleFloat(make([]int, 2), 2, []int{1}) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
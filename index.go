@@ -0,0 +1,96 @@
+package ints
+
+// Take gathers the elements of s at inds into dst, so that
+// dst[i] = s[inds[i]]. It pairs with Find, whose index lists it consumes.
+// dst must not share data with s.
+// A panic will occur if the lengths of dst and inds do not match or if an
+// index is out of range.
+func Take(dst, s, inds []int) []int {
+	if len(dst) != len(inds) {
+		panic(lengthError("Take", "dst, inds", len(dst), len(inds)))
+	}
+	for i, j := range inds {
+		if uint(j) >= uint(len(s)) {
+			panic(ErrIndexOutOfRange)
+		}
+		dst[i] = s[j]
+	}
+	return dst
+}
+
+// Put scatters vals into dst at inds, so that dst[inds[i]] = vals[i]. When
+// an index appears more than once the last value written to it is kept.
+// A panic will occur if the lengths of inds and vals do not match or if an
+// index is out of range.
+func Put(dst, inds, vals []int) []int {
+	if len(inds) != len(vals) {
+		panic(lengthError("Put", "inds, vals", len(inds), len(vals)))
+	}
+	for i, j := range inds {
+		if uint(j) >= uint(len(dst)) {
+			panic(ErrIndexOutOfRange)
+		}
+		dst[j] = vals[i]
+	}
+	return dst
+}
+
+// AddAt adds vals to dst at inds, so that dst[inds[i]] += vals[i]. Unlike
+// a gather followed by Add and Put, the values of an index appearing more
+// than once are all accumulated.
+// A panic will occur if the lengths of inds and vals do not match or if an
+// index is out of range.
+func AddAt(dst, inds, vals []int) []int {
+	if len(inds) != len(vals) {
+		panic(lengthError("AddAt", "inds, vals", len(inds), len(vals)))
+	}
+	for i, j := range inds {
+		if uint(j) >= uint(len(dst)) {
+			panic(ErrIndexOutOfRange)
+		}
+		dst[j] += vals[i]
+	}
+	return dst
+}
+
+// AddMasked adds, element-wise, s to dst where mask is true, leaving the
+// other elements of dst unchanged.
+// A panic will occur if lengths of arguments do not match.
+func AddMasked(dst []int, mask []bool, s []int) []int {
+	if len(dst) != len(s) || len(dst) != len(mask) {
+		panic(lengthError("AddMasked", "dst, mask, s", len(dst), len(mask), len(s)))
+	}
+	for i, val := range s {
+		if mask[i] {
+			dst[i] += val
+		}
+	}
+	return dst
+}
+
+// SubMasked subtracts, element-wise, t from s where mask is true, leaving
+// the other elements of s unchanged.
+// A panic will occur if lengths of arguments do not match.
+func SubMasked(s []int, mask []bool, t []int) {
+	if len(s) != len(t) || len(s) != len(mask) {
+		panic(lengthError("SubMasked", "s, mask, t", len(s), len(mask), len(t)))
+	}
+	for i, val := range t {
+		if mask[i] {
+			s[i] -= val
+		}
+	}
+}
+
+// ScaleMasked multiplies by c every element of s where mask is true.
+// A panic will occur if the lengths of mask and s do not match.
+func ScaleMasked(c int, mask []bool, s []int) {
+	if len(mask) != len(s) {
+		panic(lengthError("ScaleMasked", "mask, s", len(mask), len(s)))
+	}
+	for i := range s {
+		if mask[i] {
+			s[i] *= c
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package ints
+
+import "testing"
+
+func TestTake(t *testing.T) {
+	s := []int{10, 20, 30, 40}
+	inds, _ := Find(nil, func(v int) bool { return v > 15 }, s, -1)
+	AreSlicesEqual(t, []int{20, 30, 40}, Take(make([]int, len(inds)), s, inds), "Take found")
+	AreSlicesEqual(t, []int{40, 10, 10}, Take(make([]int, 3), s, []int{3, 0, 0}), "Take repeated")
+	if !Panics(func() { Take(make([]int, 1), s, []int{4}) }) {
+		t.Errorf("Did not panic with index out of range")
+	}
+	if !Panics(func() { Take(make([]int, 1), s, []int{-1}) }) {
+		t.Errorf("Did not panic with negative index")
+	}
+	if !Panics(func() { Take(make([]int, 2), s, []int{1}) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestPut(t *testing.T) {
+	dst := []int{0, 0, 0, 0}
+	AreSlicesEqual(t, []int{0, 7, 0, 5}, Put(dst, []int{3, 1, 3}, []int{9, 7, 5}), "Put")
+	if !Panics(func() { Put(dst, []int{4}, []int{1}) }) {
+		t.Errorf("Did not panic with index out of range")
+	}
+	if !Panics(func() { Put(dst, []int{1, 2}, []int{1}) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestAddAt(t *testing.T) {
+	dst := []int{1, 1, 1, 1}
+	AreSlicesEqual(t, []int{1, 8, 1, 15}, AddAt(dst, []int{3, 1, 3}, []int{9, 7, 5}), "AddAt")
+	if !Panics(func() { AddAt(dst, []int{-1}, []int{1}) }) {
+		t.Errorf("Did not panic with negative index")
+	}
+}
+
+func TestMasked(t *testing.T) {
+	mask := []bool{true, false, true, false}
+	dst := []int{1, 2, 3, 4}
+	AreSlicesEqual(t, []int{11, 2, 33, 4}, AddMasked(dst, mask, []int{10, 20, 30, 40}), "AddMasked")
+	SubMasked(dst, mask, []int{1, 1, 3, 1})
+	AreSlicesEqual(t, []int{10, 2, 30, 4}, dst, "SubMasked")
+	ScaleMasked(-1, mask, dst)
+	AreSlicesEqual(t, []int{-10, 2, -30, 4}, dst, "ScaleMasked")
+	if !Panics(func() { AddMasked(dst, mask[:3], dst) }) {
+		t.Errorf("Did not panic with mask length mismatch")
+	}
+	if !Panics(func() { ScaleMasked(2, mask[:3], dst) }) {
+		t.Errorf("Did not panic with mask length mismatch")
+	}
+}
@@ -5,22 +5,23 @@
 package ints
 
 import (
-	"errors"
 	//"math"
 	"sort"
+	"strconv"
 )
 
 // Add returns the element-wise sum of all the slices with the
 // results stored in the first slice.
-// For computational efficiency, it is assumed that all of
-// the variadic arguments have the same length. If this is
-// in doubt, EqLen can be used.
+// A panic will occur if the length of any of the slices does not
+// match that of dst.
 func Add(dst []int, slices ...[]int) []int {
 	if len(slices) == 0 {
 		return nil
 	}
-	if len(dst) != len(slices[0]) {
-		panic("ints: length of destination does not match length of the slices")
+	for i, slice := range slices {
+		if len(slice) != len(dst) {
+			panic(lengthError("Add", "dst, slices["+strconv.Itoa(i)+"]", len(dst), len(slice)))
+		}
 	}
 	for _, slice := range slices {
 		for j, val := range slice {
@@ -37,11 +38,24 @@ func AddConst(c int, s []int) {
 	}
 }
 
+// AddConstTo adds the value c to all of the values in s and stores the
+// result in dst. dst and s may be the same slice.
+// It panics if the lengths of dst and s are not equal.
+func AddConstTo(dst []int, c int, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("AddConstTo", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = val + c
+	}
+	return dst
+}
+
 // AddScaled performs dst = dst + alpha * s.
 // It panics if the lengths of dst and s are not equal.
 func AddScaled(dst []int, alpha int, s []int) {
 	if len(dst) != len(s) {
-		panic("ints: length of destination and source to not match")
+		panic(lengthError("AddScaled", "dst, s", len(dst), len(s)))
 	}
 	for i, val := range s {
 		dst[i] += alpha * val
@@ -52,7 +66,7 @@ func AddScaled(dst []int, alpha int, s []int) {
 // It panics if the lengths of dst, y, and s are not equal.
 func AddScaledTo(dst []int, y []int, alpha int, s []int) []int {
 	if len(dst) != len(s) || len(dst) != len(y) {
-		panic("ints: lengths of slices do not match")
+		panic(lengthError("AddScaledTo", "dst, y, s", len(dst), len(y), len(s)))
 	}
 	for i, val := range s {
 		dst[i] = y[i] + alpha*val
@@ -86,13 +100,53 @@ func Apply(f func(int) int, s []int) {
 	}
 }
 
+// ApplyIndexed stores f(i, s[i]) in dst[i] for every element of s.
+// dst and s may be the same slice.
+// It panics if the lengths of dst and s are not equal.
+func ApplyIndexed(dst []int, f func(i, val int) int, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("ApplyIndexed", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = f(i, val)
+	}
+	return dst
+}
+
+// ApplyTo applies the function f to every element of s and stores the
+// result in dst. dst and s may be the same slice.
+// It panics if the lengths of dst and s are not equal.
+func ApplyTo(dst []int, f func(int) int, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("ApplyTo", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = f(val)
+	}
+	return dst
+}
+
+// ApplyTo2 applies the function f to the element-wise pairs of a and b and
+// stores the result in dst, so that dst[i] = f(a[i], b[i]). dst may be the
+// same slice as a or b.
+// It panics if the lengths of dst, a, and b are not equal.
+func ApplyTo2(dst []int, f func(int, int) int, a, b []int) []int {
+	if len(dst) != len(a) || len(dst) != len(b) {
+		panic(lengthError("ApplyTo2", "dst, a, b", len(dst), len(a), len(b)))
+	}
+	for i, val := range a {
+		dst[i] = f(val, b[i])
+	}
+	return dst
+}
+
 // Argsort sorts the elements of s while tracking their original order.
 // At the conclusion of Argsort, s will contain the original elements of s
 // but sorted in increasing order, and inds will contain the original position
 // of the elements in the slice such that s[i] = sOrig[inds[i]].
 func Argsort(s []int, inds []int) {
 	if len(s) != len(inds) {
-		panic("ints: length of inds does not match length of slice")
+		panic(lengthError("Argsort", "s, inds", len(s), len(inds)))
 	}
 	for i := range s {
 		inds[i] = i
@@ -116,10 +170,14 @@ func Count(f func(int) bool, s []int) int {
 
 // Cumprod finds the cumulative product of the first i elements in
 // s and puts them in place into the ith element of the
-// destination. A panic will occur if lengths of do not match.
+// destination. A panic will occur if lengths of do not match
+// or if s is empty.
 func CumProd(dst, s []int) []int {
 	if len(dst) != len(s) {
-		panic("ints: length of destination does not match length of the source")
+		panic(lengthError("CumProd", "dst, s", len(dst), len(s)))
+	}
+	if len(s) == 0 {
+		panic(ErrEmpty)
 	}
 	dst[0] = s[0]
 	for i := 1; i < len(s); i++ {
@@ -130,10 +188,14 @@ func CumProd(dst, s []int) []int {
 
 // Cumsum finds the cumulative sum of the first i elements in
 // s and puts them in place into the ith element of the
-// destination. A panic will occur if lengths of arguments do not match.
+// destination. A panic will occur if lengths of arguments do not match
+// or if s is empty.
 func CumSum(dst, s []int) []int {
 	if len(dst) != len(s) {
-		panic("ints: length of destination does not match length of the source")
+		panic(lengthError("CumSum", "dst, s", len(dst), len(s)))
+	}
+	if len(s) == 0 {
+		panic(ErrEmpty)
 	}
 	dst[0] = s[0]
 	for i := 1; i < len(s); i++ {
@@ -142,12 +204,29 @@ func CumSum(dst, s []int) []int {
 	return dst
 }
 
+// Diff finds the difference between consecutive elements of s and puts
+// them in place into the destination, with the first element of the
+// destination set to s[0]. Diff is the inverse of CumSum, so that
+// CumSum(dst, Diff(dst, s)) recovers s. dst and s may be the same slice.
+// A panic will occur if lengths of arguments do not match.
+func Diff(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("Diff", "dst, s", len(dst), len(s)))
+	}
+	prev := 0
+	for i, val := range s {
+		dst[i] = val - prev
+		prev = val
+	}
+	return dst
+}
+
 // Div performs element-wise division between s
 // and t and stores the value in s. It panics if the
 // lengths of s and t are not equal.
 func Div(s []int, t []int) {
 	if len(s) != len(t) {
-		panic("ints: slice lengths do not match")
+		panic(lengthError("Div", "s, t", len(s), len(t)))
 	}
 	for i, val := range t {
 		s[i] /= val
@@ -159,7 +238,7 @@ func Div(s []int, t []int) {
 // lengths of s, t, and dst are not equal.
 func DivTo(dst []int, s []int, t []int) []int {
 	if len(s) != len(t) || len(dst) != len(t) {
-		panic("ints: slice lengths do not match")
+		panic(lengthError("DivTo", "dst, s, t", len(dst), len(s), len(t)))
 	}
 	for i, val := range t {
 		dst[i] = s[i] / val
@@ -172,7 +251,7 @@ func DivTo(dst []int, s []int, t []int) []int {
 // A panic will occur if lengths of arguments do not match.
 func Dot(s1, s2 []int) int {
 	if len(s1) != len(s2) {
-		panic("ints: lengths of the slices do not match")
+		panic(lengthError("Dot", "s1, s2", len(s1), len(s2)))
 	}
 	var sum int
 	for i, val := range s1 {
@@ -247,7 +326,8 @@ func Fill(f func() int, s []int) {
 // Find will reslice inds to have 0 length, and will append
 // found indices to inds.
 // If k > 0 and there are fewer than k elements in s satisfying f,
-// all of the found elements will be returned along with an error.
+// all of the found elements will be returned along with
+// ErrInsufficientElements.
 func Find(inds []int, f func(int) bool, s []int, k int) ([]int, error) {
 
 	// inds is also returned to allow for calling with nil
@@ -282,12 +362,15 @@ func Find(inds []int, f func(int) bool, s []int, k int) ([]int, error) {
 		}
 	}
 	// Finished iterating over the loop, which means k elements were not found
-	return inds, errors.New("ints: insufficient elements found")
+	return inds, ErrInsufficientElements
 }
 
 // Max returns the maximum value in the slice and the location of
 // the maximum value. If the input slice is empty, Max will panic.
 func Max(s []int) (max int, ind int) {
+	if len(s) == 0 {
+		panic(ErrEmpty)
+	}
 	max = s[0]
 	ind = 0
 	for i, val := range s {
@@ -302,6 +385,9 @@ func Max(s []int) (max int, ind int) {
 // Min returns the minimum value in the slice and the index of
 // the minimum value. If the input slice is empty, Min will panic.
 func Min(s []int) (min int, ind int) {
+	if len(s) == 0 {
+		panic(ErrEmpty)
+	}
 	min = s[0]
 	ind = 0
 	for i, val := range s {
@@ -318,7 +404,7 @@ func Min(s []int) (min int, ind int) {
 // lengths of s and t are not equal.
 func Mul(s []int, t []int) {
 	if len(s) != len(t) {
-		panic("ints: slice lengths do not match")
+		panic(lengthError("Mul", "s, t", len(s), len(t)))
 	}
 	for i, val := range t {
 		s[i] *= val
@@ -330,7 +416,7 @@ func Mul(s []int, t []int) {
 // lengths of s, t, and dst are not equal.
 func MulTo(dst []int, s []int, t []int) []int {
 	if len(s) != len(t) || len(dst) != len(t) {
-		panic("ints: slice lengths do not match")
+		panic(lengthError("MulTo", "dst, s, t", len(dst), len(s), len(t)))
 	}
 	for i, val := range t {
 		dst[i] = val * s[i]
@@ -385,6 +471,19 @@ func Scale(c int, s []int) {
 	}
 }
 
+// ScaleTo multiplies every element in s by c and stores the result in dst.
+// dst and s may be the same slice.
+// It panics if the lengths of dst and s are not equal.
+func ScaleTo(dst []int, c int, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("ScaleTo", "dst, s", len(dst), len(s)))
+	}
+	for i, val := range s {
+		dst[i] = val * c
+	}
+	return dst
+}
+
 // Span returns a set of N equally spaced points between l and u, where N
 // is equal to the length of the destination. The first element of the destination
 // is l, the final element of the destination is u.
@@ -392,7 +491,7 @@ func Scale(c int, s []int) {
 func Span(dst []int, l, u int) []int {
 	n := len(dst)
 	if n < 2 {
-		panic("ints: destination must have length >1")
+		panic(invalidArgument("destination must have length >1"))
 	}
 	step := (u - l) / int(n-1)
 	for i := range dst {
@@ -405,7 +504,7 @@ func Span(dst []int, l, u int) []int {
 // the lengths of s and t match (can be tested with EqLen).
 func Sub(s, t []int) {
 	if len(s) != len(t) {
-		panic("ints: length of the slices do not match")
+		panic(lengthError("Sub", "s, t", len(s), len(t)))
 	}
 	for i, val := range t {
 		s[i] -= val
@@ -415,11 +514,8 @@ func Sub(s, t []int) {
 // SubTo subtracts, element-wise, the first argument from the second and
 // stores the result in dest. Panics if the lengths of s and t do not match.
 func SubTo(dst, s, t []int) []int {
-	if len(s) != len(t) {
-		panic("ints: length of subtractor and subtractee do not match")
-	}
-	if len(dst) != len(s) {
-		panic("ints: length of destination does not match length of subtractor")
+	if len(s) != len(t) || len(dst) != len(s) {
+		panic(lengthError("SubTo", "dst, s, t", len(dst), len(s), len(t)))
 	}
 	for i, val := range t {
 		dst[i] = s[i] - val
@@ -1,6 +1,7 @@
 package ints
 
 import (
+	"errors"
 	//"math"
 	"math/rand"
 	"strconv"
@@ -45,6 +46,11 @@ func TestAdd(t *testing.T) {
 	if !Panics(func() { Add(make([]int, 2), make([]int, 3)) }) {
 		t.Errorf("Did not panic with length mismatch")
 	}
+	var lerr *LengthError
+	err := recovered(func() { Add(make([]int, 2), make([]int, 2), make([]int, 3)) })
+	if !errors.As(err, &lerr) || lerr.Args[1] != "slices[1]" {
+		t.Errorf("Add with a later length mismatch panicked with %v", err)
+	}
 }
 
 func TestAddConst(t *testing.T) {
@@ -55,6 +61,19 @@ func TestAddConst(t *testing.T) {
 	AreSlicesEqual(t, truth, s, "Wrong addition of constant")
 }
 
+func TestAddConstTo(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	s0 := append([]int(nil), s...)
+	dst := make([]int, len(s))
+	truth := []int{9, 10, 7, 13, 11}
+	AreSlicesEqual(t, truth, AddConstTo(dst, 6, s), "Wrong addition of constant")
+	AreSlicesEqual(t, s0, s, "Source modified")
+	AreSlicesEqual(t, truth, AddConstTo(s, 6, s), "Wrong in-place addition of constant")
+	if !Panics(func() { AddConstTo(make([]int, 2), 6, s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
 func TestAddScaled(t *testing.T) {
 	s := []int{3, 4, 1, 7, 5}
 	alpha := 6
@@ -112,6 +131,37 @@ func TestApply(t *testing.T) {
 	AreSlicesEqual(t, truth, s, "Wrong application of function")
 }
 
+func TestApplyIndexed(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	truth := []int{3, 5, 3, 10, 9}
+	AreSlicesEqual(t, truth, ApplyIndexed(make([]int, len(s)), func(i, val int) int { return i + val }, s), "Wrong indexed application of function")
+	if !Panics(func() { ApplyIndexed(make([]int, 2), func(i, val int) int { return val }, s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestApplyTo(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	truth := []int{6, 8, 2, 14, 10}
+	dst := ApplyTo(make([]int, len(s)), func(val int) int { return val * 2 }, s)
+	AreSlicesEqual(t, truth, dst, "Wrong application of function")
+	AreSlicesEqual(t, []int{3, 4, 1, 7, 5}, s, "Source modified")
+	if !Panics(func() { ApplyTo(make([]int, 2), func(val int) int { return val }, s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestApplyTo2(t *testing.T) {
+	a := []int{3, 4, 1, 7, 5}
+	b := []int{2, 9, 1, 3, 8}
+	truth := []int{3, 9, 1, 7, 8}
+	AreSlicesEqual(t, truth, ApplyTo2(make([]int, len(a)), func(x, y int) int { return max(x, y) }, a, b), "Wrong application of function")
+	AreSlicesEqual(t, truth, ApplyTo2(a, func(x, y int) int { return max(x, y) }, a, b), "Wrong in-place application of function")
+	if !Panics(func() { ApplyTo2(make([]int, 5), func(x, y int) int { return x }, a, b[:4]) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
 func TestArgsort(t *testing.T) {
 	s := []int{3, 4, 1, 7, 5}
 	inds := make([]int, len(s))
@@ -175,6 +225,23 @@ func TestCumSum(t *testing.T) {
 	}
 }
 
+func TestDiff(t *testing.T) {
+	s := []int{3, 7, 8, 15, 20}
+	receiver := make([]int, len(s))
+	Diff(receiver, s)
+	truth := []int{3, 4, 1, 7, 5}
+	AreSlicesEqual(t, truth, receiver, "Wrong diff returned with new receiver")
+	CumSum(receiver, receiver)
+	AreSlicesEqual(t, s, receiver, "CumSum does not invert Diff")
+	Diff(s, s)
+	AreSlicesEqual(t, truth, s, "Wrong diff returned in place")
+
+	// Test that it panics
+	if !Panics(func() { Diff(make([]int, 2), make([]int, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
 func TestDiv(t *testing.T) {
 	s1 := []int{5, 12, 27}
 	s2 := []int{1, 2, 3}
@@ -495,6 +562,16 @@ func TestScale(t *testing.T) {
 	AreSlicesEqual(t, truth, s, "Bad scaling")
 }
 
+func TestScaleTo(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	truth := []int{15, 20, 5, 35, 25}
+	AreSlicesEqual(t, truth, ScaleTo(make([]int, len(s)), 5, s), "Bad scaling")
+	AreSlicesEqual(t, []int{3, 4, 1, 7, 5}, s, "Source modified")
+	if !Panics(func() { ScaleTo(make([]int, 2), 5, s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
 // func TestSpan(t *testing.T) {
 // 	receiver := make([]int, 5)
 // 	truth := []int{1, 2, 3, 4, 5}
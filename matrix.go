@@ -0,0 +1,297 @@
+package ints
+
+import "math"
+
+// Matrix is a dense row-major matrix of int. Element (i, j) is stored in
+// Data[i*Stride+j].
+type Matrix struct {
+	Rows, Cols int
+	Stride     int
+	Data       []int
+}
+
+// NewMatrix returns an r×c matrix backed by data. If data is nil a new
+// slice is allocated. NewMatrix panics if len(data) != r*c.
+func NewMatrix(r, c int, data []int) *Matrix {
+	if r < 0 || c < 0 {
+		panic(invalidArgument("negative matrix dimension"))
+	}
+	if data == nil {
+		data = make([]int, r*c)
+	}
+	if len(data) != r*c {
+		panic(lengthError("NewMatrix", "data, r*c", len(data), r*c))
+	}
+	return &Matrix{Rows: r, Cols: c, Stride: c, Data: data}
+}
+
+// At returns element (i, j) of m.
+func (m *Matrix) At(i, j int) int {
+	m.checkIndex(i, j)
+	return m.Data[i*m.Stride+j]
+}
+
+// Set sets element (i, j) of m to val.
+func (m *Matrix) Set(i, j, val int) {
+	m.checkIndex(i, j)
+	m.Data[i*m.Stride+j] = val
+}
+
+func (m *Matrix) checkIndex(i, j int) {
+	if i < 0 || i >= m.Rows || j < 0 || j >= m.Cols {
+		panic(ErrIndexOutOfRange)
+	}
+}
+
+// Row returns row i of m. The returned slice shares the data of m.
+func (m *Matrix) Row(i int) []int {
+	if i < 0 || i >= m.Rows {
+		panic(ErrIndexOutOfRange)
+	}
+	return m.Data[i*m.Stride : i*m.Stride+m.Cols : i*m.Stride+m.Cols]
+}
+
+// Col returns a strided view of column j of m.
+func (m *Matrix) Col(j int) Vector {
+	if j < 0 || j >= m.Cols {
+		panic(ErrIndexOutOfRange)
+	}
+	return Vector{Data: m.Data, Offset: j, Stride: m.Stride, N: m.Rows}
+}
+
+// checkShapes panics if the dimensions of dst, a and b are not equal.
+func checkShapes(fn string, dst, a, b *Matrix) {
+	if dst.Rows != a.Rows || dst.Rows != b.Rows {
+		panic(lengthError(fn, "dst.Rows, a.Rows, b.Rows", dst.Rows, a.Rows, b.Rows))
+	}
+	if dst.Cols != a.Cols || dst.Cols != b.Cols {
+		panic(lengthError(fn, "dst.Cols, a.Cols, b.Cols", dst.Cols, a.Cols, b.Cols))
+	}
+}
+
+// MatAdd performs element-wise addition of a and b and stores the result in
+// dst. It panics if the dimensions of dst, a and b are not equal.
+func MatAdd(dst, a, b *Matrix) *Matrix {
+	checkShapes("MatAdd", dst, a, b)
+	for i := 0; i < dst.Rows; i++ {
+		AddScaledTo(dst.Row(i), a.Row(i), 1, b.Row(i))
+	}
+	return dst
+}
+
+// MatSub subtracts, element-wise, b from a and stores the result in dst.
+// It panics if the dimensions of dst, a and b are not equal.
+func MatSub(dst, a, b *Matrix) *Matrix {
+	checkShapes("MatSub", dst, a, b)
+	for i := 0; i < dst.Rows; i++ {
+		SubTo(dst.Row(i), a.Row(i), b.Row(i))
+	}
+	return dst
+}
+
+// MatMulElem performs element-wise multiplication of a and b and stores the
+// result in dst. It panics if the dimensions of dst, a and b are not equal.
+func MatMulElem(dst, a, b *Matrix) *Matrix {
+	checkShapes("MatMulElem", dst, a, b)
+	for i := 0; i < dst.Rows; i++ {
+		MulTo(dst.Row(i), a.Row(i), b.Row(i))
+	}
+	return dst
+}
+
+// MatVec computes the matrix-vector product m * v and stores the result
+// in dst. It panics if len(v) != m.Cols or len(dst) != m.Rows.
+func MatVec(dst []int, m *Matrix, v []int) []int {
+	if len(v) != m.Cols {
+		panic(lengthError("MatVec", "v, m.Cols", len(v), m.Cols))
+	}
+	if len(dst) != m.Rows {
+		panic(lengthError("MatVec", "dst, m.Rows", len(dst), m.Rows))
+	}
+	for i := range dst {
+		dst[i] = Dot(m.Row(i), v)
+	}
+	return dst
+}
+
+// MatMul computes the matrix product a * b and stores the result in dst.
+// dst must not share data with a or b. The result wraps around silently on
+// overflow; MatMulChecked reports it instead. MatMul panics if the
+// dimensions of the matrices are not compatible.
+func MatMul(dst, a, b *Matrix) *Matrix {
+	checkMatMul("MatMul", dst, a, b)
+	for i := 0; i < a.Rows; i++ {
+		row := dst.Row(i)
+		for j := range row {
+			row[j] = 0
+		}
+		// Accumulate scaled rows of b so the inner loop is contiguous.
+		for k, val := range a.Row(i) {
+			AddScaled(row, val, b.Row(k))
+		}
+	}
+	return dst
+}
+
+// MatMulChecked computes the matrix product a * b as MatMul does, but
+// returns ErrOverflow if any product or partial sum does not fit in an
+// int. The contents of dst are unspecified when an error is returned.
+func MatMulChecked(dst, a, b *Matrix) (*Matrix, error) {
+	checkMatMul("MatMulChecked", dst, a, b)
+	for i := 0; i < a.Rows; i++ {
+		row := dst.Row(i)
+		for j := range row {
+			row[j] = 0
+		}
+		for k, val := range a.Row(i) {
+			for j, bv := range b.Row(k) {
+				p, ok := mulChecked(val, bv)
+				if !ok {
+					return dst, ErrOverflow
+				}
+				row[j], ok = addChecked(row[j], p)
+				if !ok {
+					return dst, ErrOverflow
+				}
+			}
+		}
+	}
+	return dst, nil
+}
+
+func checkMatMul(fn string, dst, a, b *Matrix) {
+	if a.Cols != b.Rows {
+		panic(lengthError(fn, "a.Cols, b.Rows", a.Cols, b.Rows))
+	}
+	if dst.Rows != a.Rows || dst.Cols != b.Cols {
+		panic(lengthError(fn, "dst.Rows, dst.Cols, a.Rows, b.Cols", dst.Rows, dst.Cols, a.Rows, b.Cols))
+	}
+}
+
+// addChecked returns a + b and whether the sum fits in an int.
+func addChecked(a, b int) (int, bool) {
+	c := a + b
+	return c, (c > a) == (b > 0)
+}
+
+// mulChecked returns a * b and whether the product fits in an int.
+func mulChecked(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return c, false
+	}
+	return c, c/b == a
+}
+
+// Transpose stores the transpose of m in dst. dst must not share data
+// with m. It panics if dst is not m.Cols×m.Rows.
+func Transpose(dst, m *Matrix) *Matrix {
+	if dst.Rows != m.Cols || dst.Cols != m.Rows {
+		panic(lengthError("Transpose", "dst.Rows, dst.Cols, m.Cols, m.Rows", dst.Rows, dst.Cols, m.Cols, m.Rows))
+	}
+	for i := 0; i < m.Rows; i++ {
+		for j, val := range m.Row(i) {
+			dst.Data[j*dst.Stride+i] = val
+		}
+	}
+	return dst
+}
+
+// RowSums stores the sum of each row of m in dst.
+// It panics if len(dst) != m.Rows.
+func RowSums(dst []int, m *Matrix) []int {
+	if len(dst) != m.Rows {
+		panic(lengthError("RowSums", "dst, m.Rows", len(dst), m.Rows))
+	}
+	for i := range dst {
+		dst[i] = Sum(m.Row(i))
+	}
+	return dst
+}
+
+// ColSums stores the sum of each column of m in dst.
+// It panics if len(dst) != m.Cols.
+func ColSums(dst []int, m *Matrix) []int {
+	if len(dst) != m.Cols {
+		panic(lengthError("ColSums", "dst, m.Cols", len(dst), m.Cols))
+	}
+	for j := range dst {
+		dst[j] = 0
+	}
+	for i := 0; i < m.Rows; i++ {
+		Add(dst, m.Row(i))
+	}
+	return dst
+}
+
+// RowMax stores the maximum of each row of m in dst and the column of the
+// maximum in inds. inds may be nil. It panics if the lengths do not match
+// m.Rows or if m has no columns.
+func RowMax(dst, inds []int, m *Matrix) []int {
+	checkReduce("RowMax", dst, inds, m.Rows)
+	for i := range dst {
+		val, ind := Max(m.Row(i))
+		dst[i] = val
+		if inds != nil {
+			inds[i] = ind
+		}
+	}
+	return dst
+}
+
+// RowMin stores the minimum of each row of m in dst and the column of the
+// minimum in inds. inds may be nil. It panics if the lengths do not match
+// m.Rows or if m has no columns.
+func RowMin(dst, inds []int, m *Matrix) []int {
+	checkReduce("RowMin", dst, inds, m.Rows)
+	for i := range dst {
+		val, ind := Min(m.Row(i))
+		dst[i] = val
+		if inds != nil {
+			inds[i] = ind
+		}
+	}
+	return dst
+}
+
+// ColMax stores the maximum of each column of m in dst and the row of the
+// maximum in inds. inds may be nil. It panics if the lengths do not match
+// m.Cols or if m has no rows.
+func ColMax(dst, inds []int, m *Matrix) []int {
+	checkReduce("ColMax", dst, inds, m.Cols)
+	for j := range dst {
+		val, ind := m.Col(j).Max()
+		dst[j] = val
+		if inds != nil {
+			inds[j] = ind
+		}
+	}
+	return dst
+}
+
+// ColMin stores the minimum of each column of m in dst and the row of the
+// minimum in inds. inds may be nil. It panics if the lengths do not match
+// m.Cols or if m has no rows.
+func ColMin(dst, inds []int, m *Matrix) []int {
+	checkReduce("ColMin", dst, inds, m.Cols)
+	for j := range dst {
+		val, ind := m.Col(j).Min()
+		dst[j] = val
+		if inds != nil {
+			inds[j] = ind
+		}
+	}
+	return dst
+}
+
+func checkReduce(fn string, dst, inds []int, n int) {
+	if len(dst) != n {
+		panic(lengthError(fn, "dst, n", len(dst), n))
+	}
+	if inds != nil && len(inds) != n {
+		panic(lengthError(fn, "inds, n", len(inds), n))
+	}
+}
@@ -0,0 +1,138 @@
+package ints
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMatrix(t *testing.T) {
+	m := NewMatrix(2, 3, []int{1, 2, 3, 4, 5, 6})
+	if m.At(1, 2) != 6 {
+		t.Errorf("Wrong element returned")
+	}
+	m.Set(0, 1, 7)
+	AreSlicesEqual(t, []int{1, 7, 3}, m.Row(0), "Wrong row")
+	if m.Col(1).Sum() != 12 {
+		t.Errorf("Wrong column view")
+	}
+	if len(NewMatrix(3, 4, nil).Data) != 12 {
+		t.Errorf("Data not allocated")
+	}
+	if !Panics(func() { NewMatrix(2, 2, make([]int, 3)) }) {
+		t.Errorf("Did not panic with wrong data length")
+	}
+	if !Panics(func() { m.At(2, 0) }) {
+		t.Errorf("Did not panic with row out of range")
+	}
+	if !Panics(func() { m.Col(3) }) {
+		t.Errorf("Did not panic with column out of range")
+	}
+}
+
+func TestMatElementWise(t *testing.T) {
+	a := NewMatrix(2, 2, []int{1, 2, 3, 4})
+	b := NewMatrix(2, 2, []int{5, -6, 7, 8})
+	dst := NewMatrix(2, 2, nil)
+	MatAdd(dst, a, b)
+	AreSlicesEqual(t, []int{6, -4, 10, 12}, dst.Data, "Wrong MatAdd")
+	MatSub(dst, a, b)
+	AreSlicesEqual(t, []int{-4, 8, -4, -4}, dst.Data, "Wrong MatSub")
+	MatMulElem(dst, a, b)
+	AreSlicesEqual(t, []int{5, -12, 21, 32}, dst.Data, "Wrong MatMulElem")
+	if !Panics(func() { MatAdd(NewMatrix(1, 2, nil), a, b) }) {
+		t.Errorf("Did not panic with dimension mismatch")
+	}
+}
+
+func TestMatMul(t *testing.T) {
+	a := NewMatrix(2, 3, []int{1, 2, 3, 4, 5, 6})
+	b := NewMatrix(3, 2, []int{7, 8, 9, 10, 11, 12})
+	dst := NewMatrix(2, 2, []int{9, 9, 9, 9})
+	MatMul(dst, a, b)
+	truth := []int{58, 64, 139, 154}
+	AreSlicesEqual(t, truth, dst.Data, "Wrong MatMul")
+
+	dst = NewMatrix(2, 2, nil)
+	if _, err := MatMulChecked(dst, a, b); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, truth, dst.Data, "Wrong MatMulChecked")
+
+	big := NewMatrix(1, 2, []int{math.MaxInt - 1, 2})
+	col := NewMatrix(2, 1, []int{1, 1})
+	if _, err := MatMulChecked(NewMatrix(1, 1, nil), big, col); err != ErrOverflow {
+		t.Errorf("Overflowing sum returned %v", err)
+	}
+	if _, err := MatMulChecked(NewMatrix(1, 1, nil), NewMatrix(1, 1, []int{math.MaxInt / 2}), NewMatrix(1, 1, []int{3})); err != ErrOverflow {
+		t.Errorf("Overflowing product returned %v", err)
+	}
+	if _, err := MatMulChecked(NewMatrix(1, 1, nil), NewMatrix(1, 1, []int{math.MinInt}), NewMatrix(1, 1, []int{-1})); err != ErrOverflow {
+		t.Errorf("Overflowing negation returned %v", err)
+	}
+	if !Panics(func() { MatMul(dst, a, a) }) {
+		t.Errorf("Did not panic with inner dimension mismatch")
+	}
+	if !Panics(func() { MatMul(NewMatrix(3, 3, nil), a, b) }) {
+		t.Errorf("Did not panic with destination mismatch")
+	}
+	var lerr *LengthError
+	if err := recovered(func() { MatMulChecked(dst, a, a) }); !errors.As(err, &lerr) || lerr.Func != "MatMulChecked" {
+		t.Errorf("MatMulChecked with inner dimension mismatch panicked with %v", err)
+	}
+}
+
+func TestMatVec(t *testing.T) {
+	m := NewMatrix(2, 3, []int{1, 2, 3, 4, 5, 6})
+	dst := MatVec(make([]int, 2), m, []int{1, 0, -1})
+	AreSlicesEqual(t, []int{-2, -2}, dst, "Wrong MatVec")
+	if !Panics(func() { MatVec(dst, m, []int{1, 2}) }) {
+		t.Errorf("Did not panic with vector length mismatch")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	m := NewMatrix(2, 3, []int{1, 2, 3, 4, 5, 6})
+	dst := Transpose(NewMatrix(3, 2, nil), m)
+	AreSlicesEqual(t, []int{1, 4, 2, 5, 3, 6}, dst.Data, "Wrong transpose")
+	if !Panics(func() { Transpose(NewMatrix(2, 3, nil), m) }) {
+		t.Errorf("Did not panic with dimension mismatch")
+	}
+}
+
+func TestMatReductions(t *testing.T) {
+	m := NewMatrix(3, 3, []int{
+		3, 4, 1,
+		7, 5, 9,
+		-2, 8, 0,
+	})
+	AreSlicesEqual(t, []int{8, 21, 6}, RowSums(make([]int, 3), m), "Wrong RowSums")
+	AreSlicesEqual(t, []int{8, 17, 10}, ColSums(make([]int, 3), m), "Wrong ColSums")
+
+	dst, inds := make([]int, 3), make([]int, 3)
+	RowMax(dst, inds, m)
+	AreSlicesEqual(t, []int{4, 9, 8}, dst, "Wrong RowMax")
+	AreSlicesEqual(t, []int{1, 2, 1}, inds, "Wrong RowMax inds")
+	RowMin(dst, inds, m)
+	AreSlicesEqual(t, []int{1, 5, -2}, dst, "Wrong RowMin")
+	AreSlicesEqual(t, []int{2, 1, 0}, inds, "Wrong RowMin inds")
+	ColMax(dst, inds, m)
+	AreSlicesEqual(t, []int{7, 8, 9}, dst, "Wrong ColMax")
+	AreSlicesEqual(t, []int{1, 2, 1}, inds, "Wrong ColMax inds")
+	ColMin(dst, nil, m)
+	AreSlicesEqual(t, []int{-2, 4, 0}, dst, "Wrong ColMin")
+
+	if !Panics(func() { RowSums(make([]int, 2), m) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+	if !Panics(func() { RowMax(dst, make([]int, 2), m) }) {
+		t.Errorf("Did not panic with inds length mismatch")
+	}
+	// Reductions over an empty dimension report ErrEmpty either way.
+	if err := recovered(func() { ColMax(make([]int, 2), nil, NewMatrix(0, 2, nil)) }); err != ErrEmpty {
+		t.Errorf("ColMax with no rows panicked with %v", err)
+	}
+	if err := recovered(func() { RowMin(make([]int, 2), nil, NewMatrix(2, 0, nil)) }); err != ErrEmpty {
+		t.Errorf("RowMin with no columns panicked with %v", err)
+	}
+}
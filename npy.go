@@ -0,0 +1,432 @@
+package ints
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// npyMagic is the prefix of every .npy file.
+const npyMagic = "\x93NUMPY"
+
+// npyMaxHeader is the longest header ReadNpy accepts, the default
+// max_header_size of NumPy.
+const npyMaxHeader = 10000
+
+// NpyHeader describes the array stored in a .npy file.
+type NpyHeader struct {
+	// Dtype is the NumPy type descriptor of the elements, such as "<i8"
+	// or ">u2". Signed and unsigned integers of 1, 2, 4 and 8 bytes in
+	// either byte order are supported.
+	Dtype string
+	// FortranOrder reports whether the elements are stored in column-major
+	// order. The elements are returned in the order they are stored.
+	FortranOrder bool
+	// Shape is the size of each dimension of the array. A nil Shape is
+	// written as a one-dimensional array.
+	Shape []int
+}
+
+// npyDtype is a parsed integer type descriptor.
+type npyDtype struct {
+	order  binary.ByteOrder
+	size   int
+	signed bool
+}
+
+func parseDtype(descr string) (npyDtype, error) {
+	var d npyDtype
+	if len(descr) != 3 {
+		return d, fmt.Errorf("ints: npy dtype %q: %w", descr, errors.ErrUnsupported)
+	}
+	switch descr[1] {
+	case 'i':
+		d.signed = true
+	case 'u':
+	default:
+		return d, fmt.Errorf("ints: npy dtype %q: %w", descr, errors.ErrUnsupported)
+	}
+	switch descr[2] {
+	case '1', '2', '4', '8':
+		d.size = int(descr[2] - '0')
+	default:
+		return d, fmt.Errorf("ints: npy dtype %q: %w", descr, errors.ErrUnsupported)
+	}
+	switch descr[0] {
+	case '<':
+		d.order = binary.LittleEndian
+	case '>':
+		d.order = binary.BigEndian
+	case '|', '=':
+		// '|' marks types where byte order does not apply and '='
+		// marks the native byte order.
+		if descr[0] == '|' && d.size != 1 {
+			return d, malformed(fmt.Sprintf("npy dtype %q has no byte order", descr))
+		}
+		d.order = binary.NativeEndian
+	default:
+		return d, fmt.Errorf("ints: npy dtype %q: %w", descr, errors.ErrUnsupported)
+	}
+	return d, nil
+}
+
+// get decodes the element at the start of b, returning an error if it does
+// not fit in an int.
+func (d npyDtype) get(b []byte) (int, error) {
+	var u uint64
+	switch d.size {
+	case 1:
+		u = uint64(b[0])
+	case 2:
+		u = uint64(d.order.Uint16(b))
+	case 4:
+		u = uint64(d.order.Uint32(b))
+	case 8:
+		u = d.order.Uint64(b)
+	}
+	if d.signed {
+		// Sign extend from the width of the element.
+		shift := 64 - 8*uint(d.size)
+		v := int64(u<<shift) >> shift
+		if v < math.MinInt || v > math.MaxInt {
+			return 0, fmt.Errorf("%w: npy value %d does not fit in int", ErrOverflow, v)
+		}
+		return int(v), nil
+	}
+	if u > math.MaxInt {
+		return 0, fmt.Errorf("%w: npy value %d does not fit in int", ErrOverflow, u)
+	}
+	return int(u), nil
+}
+
+// put encodes v at the start of b, returning an error if it does not fit
+// in an element.
+func (d npyDtype) put(b []byte, v int) error {
+	bitSize := 8 * uint(d.size)
+	if d.signed {
+		lo, hi := int64(-1)<<(bitSize-1), int64(1)<<(bitSize-1)-1
+		if int64(v) < lo || int64(v) > hi {
+			return fmt.Errorf("%w: value %d does not fit in npy dtype i%d", ErrOverflow, v, d.size)
+		}
+	} else if v < 0 || (d.size < 8 && uint64(v) >= 1<<bitSize) {
+		return fmt.Errorf("%w: value %d does not fit in npy dtype u%d", ErrOverflow, v, d.size)
+	}
+	switch d.size {
+	case 1:
+		b[0] = byte(v)
+	case 2:
+		d.order.PutUint16(b, uint16(v))
+	case 4:
+		d.order.PutUint32(b, uint32(v))
+	case 8:
+		d.order.PutUint64(b, uint64(v))
+	}
+	return nil
+}
+
+// ReadNpy reads a .npy file of integers from r. ReadNpy will reslice dst to
+// have 0 length, and will append the elements of the array to dst, along
+// with the header describing them. An error is returned if the file is
+// malformed, its header is longer than 10000 bytes, its dtype is not an
+// integer type, or an element does not fit in an int.
+func ReadNpy(dst []int, r io.Reader) ([]int, NpyHeader, error) {
+	dst = dst[:0]
+	var h NpyHeader
+	var pre [10]byte
+	if _, err := io.ReadFull(r, pre[:]); err != nil {
+		return dst, h, err
+	}
+	if string(pre[:6]) != npyMagic {
+		return dst, h, malformed("not an npy file")
+	}
+	var hdrLen int
+	switch pre[6] {
+	case 1:
+		hdrLen = int(binary.LittleEndian.Uint16(pre[8:]))
+	case 2, 3:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return dst, h, err
+		}
+		hdrLen = int(binary.LittleEndian.Uint32([]byte{pre[8], pre[9], ext[0], ext[1]}))
+	default:
+		return dst, h, fmt.Errorf("ints: npy version %d.%d: %w", pre[6], pre[7], errors.ErrUnsupported)
+	}
+	// The header length comes from the file, so it is bounded before the
+	// header is allocated. NumPy applies the same default limit.
+	if hdrLen > npyMaxHeader {
+		return dst, h, malformed(fmt.Sprintf("npy header of %d bytes exceeds %d", hdrLen, npyMaxHeader))
+	}
+	hdr := make([]byte, hdrLen)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return dst, h, err
+	}
+	h, err := parseNpyHeader(string(hdr))
+	if err != nil {
+		return dst, h, err
+	}
+	d, err := parseDtype(h.Dtype)
+	if err != nil {
+		return dst, h, err
+	}
+	n := 1
+	for _, dim := range h.Shape {
+		if dim != 0 && n > math.MaxInt/dim {
+			return dst, h, fmt.Errorf("%w: npy shape too large", ErrOverflow)
+		}
+		n *= dim
+	}
+
+	var buf [4096]byte
+	per := len(buf) / d.size
+	for n > 0 {
+		k := min(n, per)
+		b := buf[:k*d.size]
+		if _, err := io.ReadFull(r, b); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return dst, h, err
+		}
+		for i := 0; i < k; i++ {
+			v, err := d.get(b[i*d.size:])
+			if err != nil {
+				return dst, h, err
+			}
+			dst = append(dst, v)
+		}
+		n -= k
+	}
+	return dst, h, nil
+}
+
+// WriteNpy writes s to w as a .npy file described by h. If h.Dtype is empty
+// the elements are written as little-endian 64-bit integers. WriteNpy
+// returns an error if the product of h.Shape does not equal len(s) or if
+// an element does not fit in h.Dtype; in that case nothing is written to w.
+func WriteNpy(w io.Writer, s []int, h NpyHeader) error {
+	if h.Dtype == "" {
+		h.Dtype = "<i8"
+	}
+	d, err := parseDtype(h.Dtype)
+	if err != nil {
+		return err
+	}
+	shape := h.Shape
+	if shape == nil {
+		shape = []int{len(s)}
+	}
+	n := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			return invalidArgument("negative npy dimension")
+		}
+		n *= dim
+	}
+	if n != len(s) {
+		return lengthError("WriteNpy", "s, shape", len(s), n)
+	}
+	// Check every element before the header is written, so that an
+	// element out of range does not leave a truncated file behind.
+	var scratch [8]byte
+	for _, v := range s {
+		if err := d.put(scratch[:], v); err != nil {
+			return err
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("{'descr': '")
+	sb.WriteString(h.Dtype)
+	sb.WriteString("', 'fortran_order': ")
+	if h.FortranOrder {
+		sb.WriteString("True")
+	} else {
+		sb.WriteString("False")
+	}
+	sb.WriteString(", 'shape': (")
+	for i, dim := range shape {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.Itoa(dim))
+	}
+	if len(shape) == 1 {
+		sb.WriteByte(',')
+	}
+	sb.WriteString("), }")
+
+	// The header is padded with spaces and terminated by a newline so
+	// that the data starts on a 64 byte boundary.
+	pre := []byte(npyMagic + "\x01\x00\x00\x00")
+	if sb.Len()+len(pre)+1 > math.MaxUint16 {
+		pre = []byte(npyMagic + "\x02\x00\x00\x00\x00\x00")
+	}
+	hdrLen := sb.Len() + 1
+	hdrLen += (64 - (len(pre)+hdrLen)%64) % 64
+	if pre[6] == 1 {
+		binary.LittleEndian.PutUint16(pre[8:], uint16(hdrLen))
+	} else {
+		binary.LittleEndian.PutUint32(pre[8:], uint32(hdrLen))
+	}
+	hdr := append(pre, sb.String()...)
+	for len(hdr) < len(pre)+hdrLen-1 {
+		hdr = append(hdr, ' ')
+	}
+	hdr = append(hdr, '\n')
+	if _, err := w.Write(hdr); err != nil {
+		return err
+	}
+
+	var buf [4096]byte
+	per := len(buf) / d.size
+	for len(s) > 0 {
+		k := min(len(s), per)
+		for i, v := range s[:k] {
+			if err := d.put(buf[i*d.size:], v); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(buf[:k*d.size]); err != nil {
+			return err
+		}
+		s = s[k:]
+	}
+	return nil
+}
+
+// parseNpyHeader parses the Python dictionary literal stored in the header
+// of a .npy file.
+func parseNpyHeader(s string) (NpyHeader, error) {
+	var h NpyHeader
+	p := npyParser{s: strings.TrimRight(s, " \n\x00")}
+	if !p.consume('{') {
+		return h, p.errorf("expected '{'")
+	}
+	var seen [3]bool
+	for !p.consume('}') {
+		key, err := p.str()
+		if err != nil {
+			return h, err
+		}
+		if !p.consume(':') {
+			return h, p.errorf("expected ':'")
+		}
+		switch key {
+		case "descr":
+			h.Dtype, err = p.str()
+			seen[0] = true
+		case "fortran_order":
+			h.FortranOrder, err = p.boolean()
+			seen[1] = true
+		case "shape":
+			h.Shape, err = p.tuple()
+			seen[2] = true
+		default:
+			return h, p.errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return h, err
+		}
+		if !p.consume(',') && !p.peek('}') {
+			return h, p.errorf("expected ',' or '}'")
+		}
+	}
+	if p.skipSpace(); p.pos != len(p.s) {
+		return h, p.errorf("trailing data")
+	}
+	if !seen[0] || !seen[1] || !seen[2] {
+		return h, malformed("npy header missing descr, fortran_order or shape")
+	}
+	return h, nil
+}
+
+type npyParser struct {
+	s   string
+	pos int
+}
+
+func (p *npyParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: npy header at offset %d: %s", ErrMalformed, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *npyParser) skipSpace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// peek reports whether the next non-space character is c.
+func (p *npyParser) peek(c byte) bool {
+	p.skipSpace()
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+// consume advances past the next non-space character if it is c.
+func (p *npyParser) consume(c byte) bool {
+	if p.peek(c) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *npyParser) str() (string, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) || (p.s[p.pos] != '\'' && p.s[p.pos] != '"') {
+		return "", p.errorf("expected string")
+	}
+	q := p.s[p.pos]
+	end := strings.IndexByte(p.s[p.pos+1:], q)
+	if end < 0 {
+		return "", p.errorf("unterminated string")
+	}
+	v := p.s[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+	return v, nil
+}
+
+func (p *npyParser) boolean() (bool, error) {
+	p.skipSpace()
+	switch {
+	case strings.HasPrefix(p.s[p.pos:], "True"):
+		p.pos += 4
+		return true, nil
+	case strings.HasPrefix(p.s[p.pos:], "False"):
+		p.pos += 5
+		return false, nil
+	}
+	return false, p.errorf("expected True or False")
+}
+
+func (p *npyParser) tuple() ([]int, error) {
+	if !p.consume('(') {
+		return nil, p.errorf("expected '('")
+	}
+	shape := []int{}
+	for !p.consume(')') {
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		// Python 2 era files may mark dimensions as long integers.
+		dim, err := strconv.Atoi(p.s[start:p.pos])
+		if p.pos < len(p.s) && p.s[p.pos] == 'L' {
+			p.pos++
+		}
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("expected dimension")
+		}
+		shape = append(shape, dim)
+		if !p.consume(',') && !p.peek(')') {
+			return nil, p.errorf("expected ',' or ')'")
+		}
+	}
+	return shape, nil
+}
@@ -0,0 +1,150 @@
+package ints
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// npyInt64 is the output of numpy.save of numpy.array([1, -2, 3], dtype='<i8').
+const npyInt64 = "934e554d505901007600" +
+	"7b276465736372273a20273c6938272c2027666f727472616e5f6f72646572273a2046616c73652c20277368617065273a2028332c292c207d" +
+	"2020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020200a" +
+	"0100000000000000feffffffffffffff0300000000000000"
+
+func TestReadNpy(t *testing.T) {
+	b, err := hex.DecodeString(npyInt64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, h, err := ReadNpy(nil, bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	AreSlicesEqual(t, []int{1, -2, 3}, s, "Wrong values read")
+	if h.Dtype != "<i8" || h.FortranOrder || !reflect.DeepEqual(h.Shape, []int{3}) {
+		t.Errorf("Wrong header %+v", h)
+	}
+
+	// Writing it back produces the same bytes
+	var buf bytes.Buffer
+	if err := WriteNpy(&buf, s, h); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), b) {
+		t.Errorf("Written file differs from NumPy output")
+	}
+
+	if _, _, err := ReadNpy(nil, bytes.NewReader(b[:len(b)-1])); err == nil {
+		t.Errorf("Truncated data did not return an error")
+	}
+	if _, _, err := ReadNpy(nil, strings.NewReader("not a numpy file")); err == nil {
+		t.Errorf("Bad magic did not return an error")
+	}
+	// A version 2 header claiming to be 4 GiB long is rejected before
+	// it is allocated.
+	huge := "\x93NUMPY\x02\x00\xff\xff\xff\xff"
+	if _, _, err := ReadNpy(nil, strings.NewReader(huge)); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Oversized header returned %v", err)
+	}
+}
+
+func TestNpyRoundTrip(t *testing.T) {
+	s := []int{0, 1, -1, 127, -128, 5, 6}
+	for _, dtype := range []string{"|i1", "<i2", ">i2", "<i4", ">i4", "<i8", ">i8", "=i8"} {
+		var buf bytes.Buffer
+		h := NpyHeader{Dtype: dtype, Shape: []int{7, 1}}
+		if err := WriteNpy(&buf, s, h); err != nil {
+			t.Fatalf("%v: %v", dtype, err)
+		}
+		if (buf.Len()-len(s)*int(dtype[2]-'0'))%64 != 0 {
+			t.Errorf("%v: data does not start on a 64 byte boundary", dtype)
+		}
+		got, gotH, err := ReadNpy([]int{4, 5}, &buf)
+		if err != nil {
+			t.Fatalf("%v: %v", dtype, err)
+		}
+		AreSlicesEqual(t, s, got, dtype+" round trip")
+		if !reflect.DeepEqual(gotH, h) {
+			t.Errorf("%v: header %+v, want %+v", dtype, gotH, h)
+		}
+	}
+
+	u := []int{0, 255, 65535, math.MaxInt64}
+	var buf bytes.Buffer
+	if err := WriteNpy(&buf, u, NpyHeader{Dtype: ">u8", FortranOrder: true, Shape: []int{2, 2}}); err != nil {
+		t.Fatal(err)
+	}
+	got, h, err := ReadNpy(nil, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AreSlicesEqual(t, u, got, "Unsigned round trip")
+	if !h.FortranOrder {
+		t.Errorf("Fortran order not preserved")
+	}
+}
+
+func TestNpyOverflow(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteNpy(&buf, []int{128}, NpyHeader{Dtype: "|i1"}); err == nil {
+		t.Errorf("Overflowing i1 did not return an error")
+	}
+	if err := WriteNpy(&buf, []int{256}, NpyHeader{Dtype: "|u1"}); err == nil {
+		t.Errorf("Overflowing u1 did not return an error")
+	}
+	if err := WriteNpy(&buf, []int{-1}, NpyHeader{Dtype: "<u4"}); err == nil {
+		t.Errorf("Negative u4 did not return an error")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Overflowing elements wrote %d bytes", buf.Len())
+	}
+	if err := WriteNpy(&buf, []int{1, 300}, NpyHeader{Dtype: "|u1"}); !errors.Is(err, ErrOverflow) || buf.Len() != 0 {
+		t.Errorf("Overflow after the first element returned %v and wrote %d bytes", err, buf.Len())
+	}
+	if err := WriteNpy(&buf, []int{1, 2}, NpyHeader{Shape: []int{3}}); err == nil {
+		t.Errorf("Shape mismatch did not return an error")
+	}
+	if err := WriteNpy(&buf, []int{1}, NpyHeader{Dtype: "<f8"}); err == nil {
+		t.Errorf("Float dtype did not return an error")
+	}
+
+	buf.Reset()
+	// Write the bytes of MaxUint64 as an unsigned array.
+	if err := WriteNpy(&buf, []int{-1}, NpyHeader{Dtype: "<i8"}); err != nil {
+		t.Fatal(err)
+	}
+	b := bytes.Replace(buf.Bytes(), []byte("<i8"), []byte("<u8"), 1)
+	if _, _, err := ReadNpy(nil, bytes.NewReader(b)); err == nil {
+		t.Errorf("Overflowing u8 did not return an error")
+	}
+}
+
+func TestParseNpyHeader(t *testing.T) {
+	for _, test := range []struct {
+		hdr   string
+		want  NpyHeader
+		valid bool
+	}{
+		{"{'descr': '<i4', 'fortran_order': False, 'shape': (2, 3), }", NpyHeader{"<i4", false, []int{2, 3}}, true},
+		{"{'shape': (), 'fortran_order': True, 'descr': '>u2'}  \n", NpyHeader{">u2", true, []int{}}, true},
+		{"{\"descr\": \"<i8\", \"fortran_order\": False, \"shape\": (4L,)}", NpyHeader{"<i8", false, []int{4}}, true},
+		{"{'descr': '<i4', 'shape': (2,)}", NpyHeader{}, false},
+		{"{'descr': '<i4', 'fortran_order': Maybe, 'shape': (2,)}", NpyHeader{}, false},
+		{"{'descr': '<i4', 'fortran_order': False, 'shape': (2,x)}", NpyHeader{}, false},
+		{"{'descr': '<i4', 'fortran_order': False, 'shape': (2,), 'extra': 1}", NpyHeader{}, false},
+	} {
+		h, err := parseNpyHeader(test.hdr)
+		if (err == nil) != test.valid {
+			t.Errorf("%q: unexpected error state %v", test.hdr, err)
+			continue
+		}
+		if test.valid && !reflect.DeepEqual(h, test.want) {
+			t.Errorf("%q: got %+v, want %+v", test.hdr, h, test.want)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package ints
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// BlockSize is the maximum number of values held by a PackedBlock.
+const BlockSize = 128
+
+// PackedBlock is a frame-of-reference encoding of up to BlockSize values.
+// The minimum value is stored once and every value is stored as its offset
+// from the minimum using the smallest bit width that fits the largest
+// offset. Individual values can be read with At without unpacking the
+// block.
+type PackedBlock struct {
+	n     int
+	min   int
+	width uint
+	words []uint64
+}
+
+// Pack encodes the values of s into the block, replacing its contents.
+// The storage of the block is reused when it is large enough.
+// Pack panics if len(s) > BlockSize.
+func (b *PackedBlock) Pack(s []int) {
+	if len(s) > BlockSize {
+		panic(invalidArgument("slice longer than block size"))
+	}
+	b.n = len(s)
+	b.min = 0
+	b.width = 0
+	b.words = b.words[:0]
+	if len(s) == 0 {
+		return
+	}
+	b.min, _ = Min(s)
+	var maxOff uint64
+	for _, val := range s {
+		// The subtraction is done on unsigned values so that the
+		// offset of any value from the minimum fits in 64 bits.
+		maxOff |= uint64(val) - uint64(b.min)
+	}
+	b.width = uint(bits.Len64(maxOff))
+	nWords := (b.n*int(b.width) + 63) / 64
+	if cap(b.words) < nWords {
+		b.words = make([]uint64, nWords)
+	}
+	b.words = b.words[:nWords]
+	for i := range b.words {
+		b.words[i] = 0
+	}
+	if b.width == 0 {
+		return
+	}
+	for i, val := range s {
+		off := uint64(val) - uint64(b.min)
+		pos := uint(i) * b.width
+		w, shift := pos/64, pos%64
+		b.words[w] |= off << shift
+		if shift+b.width > 64 {
+			b.words[w+1] |= off >> (64 - shift)
+		}
+	}
+}
+
+// Len returns the number of values in the block.
+func (b *PackedBlock) Len() int {
+	return b.n
+}
+
+// Width returns the number of bits used to store each value.
+func (b *PackedBlock) Width() int {
+	return int(b.width)
+}
+
+// At returns the i-th value of the block. It panics if i is out of range.
+func (b *PackedBlock) At(i int) int {
+	if i < 0 || i >= b.n {
+		panic(ErrIndexOutOfRange)
+	}
+	if b.width == 0 {
+		return b.min
+	}
+	pos := uint(i) * b.width
+	w, shift := pos/64, pos%64
+	off := b.words[w] >> shift
+	if shift+b.width > 64 {
+		off |= b.words[w+1] << (64 - shift)
+	}
+	off &= ^uint64(0) >> (64 - b.width)
+	return int(uint64(b.min) + off)
+}
+
+// Unpack decodes all of the values in the block into dst.
+// It panics if len(dst) != b.Len().
+func (b *PackedBlock) Unpack(dst []int) []int {
+	if len(dst) != b.n {
+		panic(lengthError("Unpack", "dst, b", len(dst), b.n))
+	}
+	for i := range dst {
+		dst[i] = b.At(i)
+	}
+	return dst
+}
+
+// AppendBinary appends the serialized form of the block to dst and returns
+// the extended buffer. The serialized form is the number of values, the
+// zigzag encoded minimum and the bit width, followed by the packed offsets
+// as little-endian 64-bit words.
+func (b *PackedBlock) AppendBinary(dst []byte) ([]byte, error) {
+	dst = binary.AppendUvarint(dst, uint64(b.n))
+	dst = binary.AppendUvarint(dst, zigzag(b.min))
+	dst = append(dst, byte(b.width))
+	for _, w := range b.words {
+		dst = binary.LittleEndian.AppendUint64(dst, w)
+	}
+	return dst, nil
+}
+
+// MarshalBinary returns the serialized form of the block.
+func (b *PackedBlock) MarshalBinary() ([]byte, error) {
+	return b.AppendBinary(nil)
+}
+
+// UnmarshalBinary replaces the contents of the block with the serialized
+// block in data. It returns an error if data does not hold exactly one
+// block.
+func (b *PackedBlock) UnmarshalBinary(data []byte) error {
+	n, err := b.decode(data)
+	if err != nil {
+		return err
+	}
+	if n != len(data) {
+		return malformed("trailing data after packed block")
+	}
+	return nil
+}
+
+var errPackedBlock = malformed("packed block")
+
+// decode reads a serialized block from the start of data and returns the
+// number of bytes consumed.
+func (b *PackedBlock) decode(data []byte) (int, error) {
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n > BlockSize {
+		return 0, errPackedBlock
+	}
+	read := k
+	base, k := binary.Uvarint(data[read:])
+	if k <= 0 {
+		return 0, errPackedBlock
+	}
+	read += k
+	if read >= len(data) || data[read] > 64 {
+		return 0, errPackedBlock
+	}
+	width := uint(data[read])
+	read++
+	nWords := (int(n)*int(width) + 63) / 64
+	if len(data)-read < 8*nWords {
+		return 0, errPackedBlock
+	}
+	b.n = int(n)
+	b.min = unzigzag(base)
+	b.width = width
+	if cap(b.words) < nWords {
+		b.words = make([]uint64, nWords)
+	}
+	b.words = b.words[:nWords]
+	for i := range b.words {
+		b.words[i] = binary.LittleEndian.Uint64(data[read:])
+		read += 8
+	}
+	return read, nil
+}
+
+// AppendPacked splits s into blocks of BlockSize values, packs each block
+// and appends the serialized blocks to dst, returning the extended buffer.
+func AppendPacked(dst []byte, s []int) []byte {
+	var b PackedBlock
+	for len(s) > 0 {
+		n := min(len(s), BlockSize)
+		b.Pack(s[:n])
+		dst, _ = b.AppendBinary(dst)
+		s = s[n:]
+	}
+	return dst
+}
+
+// UnpackInto decodes the serialized blocks in data written by AppendPacked.
+// UnpackInto will reslice dst to have 0 length, and will append the decoded
+// values to dst. If data is malformed, the values of the blocks decoded
+// before the error are returned along with the error.
+func UnpackInto(dst []int, data []byte) ([]int, error) {
+	dst = dst[:0]
+	var b PackedBlock
+	for len(data) > 0 {
+		n, err := b.decode(data)
+		if err != nil {
+			return dst, err
+		}
+		for i := 0; i < b.n; i++ {
+			dst = append(dst, b.At(i))
+		}
+		data = data[n:]
+	}
+	return dst, nil
+}
@@ -0,0 +1,104 @@
+package ints
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPackedBlock(t *testing.T) {
+	for _, s := range [][]int{
+		{},
+		{7, 7, 7},
+		{1000, 1003, 1001, 1007, 1000},
+		{-5, 3, -1, 0},
+		{math.MinInt64, math.MaxInt64, 0},
+	} {
+		var b PackedBlock
+		b.Pack(s)
+		if b.Len() != len(s) {
+			t.Errorf("Wrong length %v for %v", b.Len(), s)
+		}
+		for i, val := range s {
+			if got := b.At(i); got != val {
+				t.Errorf("At(%v) = %v, want %v for %v", i, got, val, s)
+			}
+		}
+		AreSlicesEqual(t, s, b.Unpack(make([]int, len(s))), "Unpack mismatch")
+
+		data, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var c PackedBlock
+		if err := c.UnmarshalBinary(data); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		AreSlicesEqual(t, s, c.Unpack(make([]int, c.Len())), "Round trip mismatch")
+	}
+
+	var b PackedBlock
+	b.Pack([]int{1000, 1003, 1001, 1007, 1000})
+	if b.Width() != 3 {
+		t.Errorf("Wrong width %v, want 3", b.Width())
+	}
+	if !Panics(func() { b.At(5) }) {
+		t.Errorf("Did not panic with index out of range")
+	}
+	if !Panics(func() { b.Pack(make([]int, BlockSize+1)) }) {
+		t.Errorf("Did not panic with too many values")
+	}
+	if !Panics(func() { b.Unpack(make([]int, 2)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestPackedBlockWidths(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	s := make([]int, BlockSize)
+	for width := 0; width <= 64; width++ {
+		for i := range s {
+			s[i] = -1 << 40
+			if width > 0 {
+				s[i] += int(rnd.Uint64() >> (64 - width))
+			}
+		}
+		var b PackedBlock
+		b.Pack(s)
+		if b.Width() > width {
+			t.Errorf("Width %v exceeds %v", b.Width(), width)
+		}
+		for i, val := range s {
+			if got := b.At(i); got != val {
+				t.Errorf("Width %v: At(%v) = %v, want %v", width, i, got, val)
+				break
+			}
+		}
+	}
+}
+
+func TestAppendPacked(t *testing.T) {
+	s := make([]int, 3*BlockSize+17)
+	for i := range s {
+		s[i] = 5*i + i%3
+	}
+	data := AppendPacked(nil, s)
+	got, err := UnpackInto(nil, data)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	AreSlicesEqual(t, s, got, "Round trip mismatch")
+
+	got, err = UnpackInto(got, data[:len(data)-1])
+	if err == nil {
+		t.Errorf("Truncated input did not return an error")
+	}
+	if len(got) != 3*BlockSize {
+		t.Errorf("Wrong number of values %v before truncated block", len(got))
+	}
+
+	var b PackedBlock
+	if err := b.UnmarshalBinary(data); err == nil {
+		t.Errorf("Trailing data did not return an error")
+	}
+}
@@ -0,0 +1,165 @@
+package ints
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelThreshold is the length below which the parallel scans run the
+// serial loop, as the cost of starting goroutines outweighs the gain.
+const parallelThreshold = 1 << 16
+
+// parallelism controls how a parallel scan is split. The exported scans use
+// defaultParallelism; tests pass their own so that the blocked algorithm is
+// exercised without changing process-wide settings.
+type parallelism struct {
+	procs     int // the number of blocks to split into
+	threshold int // the length below which the serial loop is used
+}
+
+func defaultParallelism() parallelism {
+	return parallelism{procs: runtime.GOMAXPROCS(0), threshold: parallelThreshold}
+}
+
+// ParallelCumSum computes the same result as CumSum using several
+// goroutines. The slice is split into one block per processor; each block
+// is scanned independently, the block totals are combined serially, and
+// the resulting offsets are added to each block independently. Because
+// integer addition is associative, even when it overflows, the result is
+// identical to that of CumSum. Slices shorter than 65536 elements are
+// scanned serially. dst and s may be the same slice.
+// A panic will occur if lengths of arguments do not match or if s is empty.
+func ParallelCumSum(dst, s []int) []int {
+	return parallelCumSum(dst, s, defaultParallelism())
+}
+
+func parallelCumSum(dst, s []int, p parallelism) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("ParallelCumSum", "dst, s", len(dst), len(s)))
+	}
+	if len(s) == 0 {
+		panic(ErrEmpty)
+	}
+	p.scan(dst, s,
+		func(dst, s []int) { CumSum(dst, s) },
+		func(a, b int) int { return a + b },
+		AddConst,
+	)
+	return dst
+}
+
+// ParallelCumProd computes the same result as CumProd using several
+// goroutines, in the manner of ParallelCumSum. dst and s may be the same
+// slice. A panic will occur if lengths of arguments do not match or if s
+// is empty.
+func ParallelCumProd(dst, s []int) []int {
+	return parallelCumProd(dst, s, defaultParallelism())
+}
+
+func parallelCumProd(dst, s []int, p parallelism) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("ParallelCumProd", "dst, s", len(dst), len(s)))
+	}
+	if len(s) == 0 {
+		panic(ErrEmpty)
+	}
+	p.scan(dst, s,
+		func(dst, s []int) { CumProd(dst, s) },
+		func(a, b int) int { return a * b },
+		Scale,
+	)
+	return dst
+}
+
+// ParallelScan computes the same result as Scan using several goroutines,
+// in the manner of ParallelCumSum. f must be associative, and is called
+// concurrently. If f panics, the panic is raised again in the calling
+// goroutine once all the goroutines have finished. dst and s may be the
+// same slice.
+// A panic will occur if lengths of arguments do not match.
+func ParallelScan(dst []int, f func(acc, val int) int, s []int) []int {
+	return parallelScanFunc(dst, f, s, defaultParallelism())
+}
+
+func parallelScanFunc(dst []int, f func(acc, val int) int, s []int, p parallelism) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("ParallelScan", "dst, s", len(dst), len(s)))
+	}
+	p.scan(dst, s,
+		func(dst, s []int) { Scan(dst, f, s) },
+		f,
+		func(prefix int, dst []int) {
+			for i, val := range dst {
+				dst[i] = f(prefix, val)
+			}
+		},
+	)
+	return dst
+}
+
+// scan performs a blocked two-pass scan. scan performs a serial scan of a
+// block, combine is the associative operation of the scan, and apply
+// combines a prefix with every element of a scanned block.
+func (p parallelism) scan(dst, s []int, scan func(dst, s []int), combine func(a, b int) int, apply func(prefix int, dst []int)) {
+	n := len(s)
+	nBlocks := p.procs
+	if n < max(p.threshold, 2) || nBlocks < 2 {
+		scan(dst, s)
+		return
+	}
+	nBlocks = min(nBlocks, n)
+	size := (n + nBlocks - 1) / nBlocks
+	nBlocks = (n + size - 1) / size
+	block := func(b int) (int, int) {
+		return b * size, min((b+1)*size, n)
+	}
+
+	// First pass: scan every block independently.
+	runBlocks(0, nBlocks, func(b int) {
+		lo, hi := block(b)
+		scan(dst[lo:hi], s[lo:hi])
+	})
+
+	// Combine the block totals into the prefix preceding each block.
+	prefixes := make([]int, nBlocks)
+	_, hi := block(0)
+	prefixes[1] = dst[hi-1]
+	for b := 2; b < nBlocks; b++ {
+		_, hi := block(b - 1)
+		prefixes[b] = combine(prefixes[b-1], dst[hi-1])
+	}
+
+	// Second pass: apply the prefixes to every block but the first.
+	runBlocks(1, nBlocks, func(b int) {
+		lo, hi := block(b)
+		apply(prefixes[b], dst[lo:hi])
+	})
+}
+
+// runBlocks calls f for each block in [from, to) in its own goroutine and
+// waits for them all. A panic in any of the goroutines would otherwise
+// terminate the process, so the first one is recovered and raised again in
+// the calling goroutine.
+func runBlocks(from, to int, f func(b int)) {
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		panicVal any
+	)
+	for b := from; b < to; b++ {
+		wg.Add(1)
+		go func(b int) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { panicVal = r })
+				}
+			}()
+			f(b)
+		}(b)
+	}
+	wg.Wait()
+	if panicVal != nil {
+		panic(panicVal)
+	}
+}
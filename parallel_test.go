@@ -0,0 +1,84 @@
+package ints
+
+import (
+	"math"
+	"testing"
+)
+
+// blocked splits scans of any length into four blocks, so that the blocked
+// algorithm is exercised whatever the number of processors.
+var blocked = parallelism{procs: 4}
+
+func TestParallelCumSum(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5, 17, 1000} {
+		s := RandomSlice(n)
+		truth := CumSum(make([]int, n), s)
+		AreSlicesEqual(t, truth, parallelCumSum(make([]int, n), s, blocked), "Wrong parallel cumsum")
+		parallelCumSum(s, s, blocked)
+		AreSlicesEqual(t, truth, s, "Wrong parallel cumsum in place")
+	}
+	if !Panics(func() { parallelCumSum(make([]int, 2), make([]int, 3), blocked) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+	if !Panics(func() { parallelCumSum(nil, nil, blocked) }) {
+		t.Errorf("Did not panic with empty slice")
+	}
+	s := []int{3, 4, 1, 7, 5}
+	AreSlicesEqual(t, []int{3, 7, 8, 15, 20}, ParallelCumSum(make([]int, 5), s), "Wrong serial fallback")
+}
+
+func TestParallelCumProd(t *testing.T) {
+	for _, n := range []int{1, 4, 13, 1000} {
+		s := RandomSlice(n)
+		truth := CumProd(make([]int, n), s)
+		AreSlicesEqual(t, truth, parallelCumProd(make([]int, n), s, blocked), "Wrong parallel cumprod")
+	}
+}
+
+func TestParallelScan(t *testing.T) {
+	s := RandomSlice(1000)
+	f := func(a, b int) int { return max(a, b) }
+	truth := CumMax(make([]int, len(s)), s)
+	AreSlicesEqual(t, truth, parallelScanFunc(make([]int, len(s)), f, s, blocked), "Wrong parallel scan")
+	if len(parallelScanFunc(nil, f, nil, blocked)) != 0 {
+		t.Errorf("Scan of empty slice not empty")
+	}
+
+	// A panic in f is raised in the calling goroutine rather than
+	// terminating the process.
+	checked := func(a, b int) int {
+		c, ok := addChecked(a, b)
+		if !ok {
+			panic(ErrOverflow)
+		}
+		return c
+	}
+	big := []int{1, 2, math.MaxInt, 3, 4, 5, 6, 7}
+	if err := recovered(func() { parallelScanFunc(make([]int, len(big)), checked, big, blocked) }); err != ErrOverflow {
+		t.Errorf("Panicking scan raised %v", err)
+	}
+}
+
+func benchmarkCumSum(b *testing.B, cumsum func(dst, s []int) []int, s []int) {
+	dst := make([]int, len(s))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cumsum(dst, s)
+	}
+}
+
+func BenchmarkCumSumLarge(b *testing.B) {
+	benchmarkCumSum(b, CumSum, RandomSlice(LARGE))
+}
+
+func BenchmarkCumSumHuge(b *testing.B) {
+	benchmarkCumSum(b, CumSum, RandomSlice(HUGE))
+}
+
+func BenchmarkParallelCumSumLarge(b *testing.B) {
+	benchmarkCumSum(b, ParallelCumSum, RandomSlice(LARGE))
+}
+
+func BenchmarkParallelCumSumHuge(b *testing.B) {
+	benchmarkCumSum(b, ParallelCumSum, RandomSlice(HUGE))
+}
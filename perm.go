@@ -0,0 +1,202 @@
+package ints
+
+// A permutation of length n is a slice containing each of 0, ..., n-1
+// exactly once, such as the inds produced by Argsort. Applying the
+// permutation p to s produces the slice whose ith element is s[p[i]].
+
+// markPermutation checks that p is a permutation, panicking otherwise,
+// and marks every element of p by complementing it so that cycles can be
+// followed without allocating. unmark restores p.
+func markPermutation(p []int) {
+	for i, v := range p {
+		if v < 0 {
+			v = ^v
+		}
+		if v >= len(p) || p[v] < 0 {
+			// Restore the elements marked so far before panicking.
+			for j := range p[:i] {
+				w := p[j]
+				if w < 0 {
+					w = ^w
+				}
+				if w < len(p) && p[w] < 0 {
+					p[w] = ^p[w]
+				}
+			}
+			panic(ErrNotPermutation)
+		}
+		p[v] = ^p[v]
+	}
+}
+
+func unmark(p []int) {
+	for i, v := range p {
+		if v < 0 {
+			p[i] = ^v
+		}
+	}
+}
+
+// ApplyPermutation permutes s in place so that s[i] becomes the original
+// s[p[i]]. Applying the inds returned by Argsort to a copy of the original
+// slice sorts it. The cycles of p are followed so that no memory is
+// allocated; p is modified during the call but restored before it returns.
+// ApplyPermutation panics if the lengths of s and p do not match or if p
+// is not a permutation.
+func ApplyPermutation(s, p []int) {
+	if len(s) != len(p) {
+		panic(lengthError("ApplyPermutation", "s, p", len(s), len(p)))
+	}
+	markPermutation(p)
+	// After marking, every element of p is complemented. Elements are
+	// restored as their cycle is applied, so a non-negative element marks
+	// a visited position.
+	for i := range p {
+		if p[i] >= 0 {
+			continue
+		}
+		tmp := s[i]
+		j := i
+		for {
+			k := ^p[j]
+			p[j] = k
+			if k == i {
+				s[j] = tmp
+				break
+			}
+			s[j] = s[k]
+			j = k
+		}
+	}
+}
+
+// InvertPermutation stores the inverse of p in dst, so that applying p and
+// then dst leaves a slice unchanged. It panics if the lengths of dst and p
+// do not match or if p is not a permutation.
+func InvertPermutation(dst, p []int) []int {
+	if len(dst) != len(p) {
+		panic(lengthError("InvertPermutation", "dst, p", len(dst), len(p)))
+	}
+	for i := range dst {
+		dst[i] = -1
+	}
+	for i, v := range p {
+		if v < 0 || v >= len(p) || dst[v] != -1 {
+			panic(ErrNotPermutation)
+		}
+		dst[v] = i
+	}
+	return dst
+}
+
+// ComposePermutations stores in dst the permutation equivalent to applying
+// p and then q, that is dst[i] = p[q[i]]. dst must not share data with p
+// or q. It panics if the lengths do not match or if p or q is not a
+// permutation.
+func ComposePermutations(dst, p, q []int) []int {
+	if len(dst) != len(p) || len(p) != len(q) {
+		panic(lengthError("ComposePermutations", "dst, p, q", len(dst), len(p), len(q)))
+	}
+	if !IsPermutation(p) || !IsPermutation(q) {
+		panic(ErrNotPermutation)
+	}
+	for i, v := range q {
+		dst[i] = p[v]
+	}
+	return dst
+}
+
+// IsPermutation returns true if p contains each of 0, ..., len(p)-1
+// exactly once.
+func IsPermutation(p []int) bool {
+	seen := make([]uint64, (len(p)+63)/64)
+	for _, v := range p {
+		if v < 0 || v >= len(p) || seen[v/64]&(1<<(uint(v)%64)) != 0 {
+			return false
+		}
+		seen[v/64] |= 1 << (uint(v) % 64)
+	}
+	return true
+}
+
+// Parity returns 0 if p is an even permutation and 1 if it is odd. p is
+// modified during the call but restored before it returns. Parity panics
+// if p is not a permutation.
+func Parity(p []int) int {
+	markPermutation(p)
+	cycles := 0
+	for i := range p {
+		if p[i] >= 0 {
+			continue
+		}
+		cycles++
+		for j := i; p[j] < 0; {
+			p[j] = ^p[j]
+			j = p[j]
+		}
+	}
+	return (len(p) - cycles) % 2
+}
+
+// CycleDecomposition returns the cycles of p, each starting with its
+// smallest element and ordered by that element. Following a cycle c,
+// p[c[k]] = c[k+1] and p[c[len(c)-1]] = c[0]. Fixed points are returned
+// as cycles of length one. CycleDecomposition panics if p is not a
+// permutation.
+func CycleDecomposition(p []int) [][]int {
+	if !IsPermutation(p) {
+		panic(ErrNotPermutation)
+	}
+	visited := make([]bool, len(p))
+	var cycles [][]int
+	for i := range p {
+		if visited[i] {
+			continue
+		}
+		var c []int
+		for j := i; !visited[j]; j = p[j] {
+			visited[j] = true
+			c = append(c, j)
+		}
+		cycles = append(cycles, c)
+	}
+	return cycles
+}
+
+// NextPermutation rearranges s into the next permutation of its elements
+// in lexicographic order and returns true. If s is already the last
+// permutation, in decreasing order, it is rearranged into the first, in
+// increasing order, and NextPermutation returns false.
+func NextPermutation(s []int) bool {
+	return stepPermutation(s, func(a, b int) bool { return a < b })
+}
+
+// PrevPermutation rearranges s into the previous permutation of its
+// elements in lexicographic order and returns true. If s is already the
+// first permutation, in increasing order, it is rearranged into the last,
+// in decreasing order, and PrevPermutation returns false.
+func PrevPermutation(s []int) bool {
+	return stepPermutation(s, func(a, b int) bool { return a > b })
+}
+
+// stepPermutation advances s to the next permutation in the order defined
+// by less.
+func stepPermutation(s []int, less func(a, b int) bool) bool {
+	// Find the longest non-increasing suffix.
+	i := len(s) - 1
+	for i > 0 && !less(s[i-1], s[i]) {
+		i--
+	}
+	if i > 0 {
+		// Swap the pivot with the rightmost element exceeding it.
+		j := len(s) - 1
+		for !less(s[i-1], s[j]) {
+			j--
+		}
+		s[i-1], s[j] = s[j], s[i-1]
+	}
+	for l, r := i, len(s)-1; l < r; l, r = l+1, r-1 {
+		s[l], s[r] = s[r], s[l]
+	}
+	return i > 0
+}
@@ -0,0 +1,138 @@
+package ints
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestApplyPermutation(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	orig := []int{3, 4, 1, 7, 5}
+	inds := make([]int, len(s))
+	Argsort(s, inds)
+	indsOrig := append([]int(nil), inds...)
+
+	ApplyPermutation(orig, inds)
+	AreSlicesEqual(t, s, orig, "Applying Argsort inds did not sort")
+	AreSlicesEqual(t, indsOrig, inds, "Permutation not restored")
+
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 50; n++ {
+		p := rnd.Perm(n)
+		s := RandomSlice(n)
+		want := make([]int, n)
+		for i, v := range p {
+			want[i] = s[v]
+		}
+		ApplyPermutation(s, p)
+		AreSlicesEqual(t, want, s, "Wrong permutation applied")
+	}
+
+	bad := []int{0, 2, 2}
+	if !Panics(func() { ApplyPermutation(make([]int, 3), bad) }) {
+		t.Errorf("Did not panic with invalid permutation")
+	}
+	AreSlicesEqual(t, []int{0, 2, 2}, bad, "Invalid permutation not restored")
+	if !Panics(func() { ApplyPermutation(make([]int, 2), []int{0, 1, 2}) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestInvertPermutation(t *testing.T) {
+	p := []int{2, 0, 3, 1}
+	inv := InvertPermutation(make([]int, 4), p)
+	AreSlicesEqual(t, []int{1, 3, 0, 2}, inv, "Wrong inverse")
+	s := []int{10, 11, 12, 13}
+	ApplyPermutation(s, p)
+	ApplyPermutation(s, inv)
+	AreSlicesEqual(t, []int{10, 11, 12, 13}, s, "Inverse does not undo permutation")
+	if !Panics(func() { InvertPermutation(make([]int, 3), []int{0, 0, 1}) }) {
+		t.Errorf("Did not panic with invalid permutation")
+	}
+}
+
+func TestComposePermutations(t *testing.T) {
+	p := []int{2, 0, 3, 1}
+	q := []int{1, 2, 3, 0}
+	r := ComposePermutations(make([]int, 4), p, q)
+	s1 := []int{10, 11, 12, 13}
+	ApplyPermutation(s1, p)
+	ApplyPermutation(s1, q)
+	s2 := []int{10, 11, 12, 13}
+	ApplyPermutation(s2, r)
+	AreSlicesEqual(t, s1, s2, "Composition does not match applying in turn")
+	if !Panics(func() { ComposePermutations(make([]int, 4), p, []int{0, 1, 2, 4}) }) {
+		t.Errorf("Did not panic with invalid permutation")
+	}
+}
+
+func TestIsPermutation(t *testing.T) {
+	if !IsPermutation([]int{}) || !IsPermutation([]int{1, 2, 0}) {
+		t.Errorf("Permutation reported as invalid")
+	}
+	if IsPermutation([]int{0, 0}) || IsPermutation([]int{0, 2}) || IsPermutation([]int{-1, 0}) {
+		t.Errorf("Invalid permutation reported as valid")
+	}
+}
+
+func TestParity(t *testing.T) {
+	for _, test := range []struct {
+		p      []int
+		parity int
+	}{
+		{[]int{}, 0},
+		{[]int{0, 1, 2}, 0},
+		{[]int{1, 0, 2}, 1},
+		{[]int{1, 2, 0}, 0},
+		{[]int{3, 2, 1, 0}, 0},
+		{[]int{1, 2, 3, 0}, 1},
+	} {
+		orig := append([]int(nil), test.p...)
+		if got := Parity(test.p); got != test.parity {
+			t.Errorf("Parity(%v) = %v, want %v", orig, got, test.parity)
+		}
+		AreSlicesEqual(t, orig, test.p, "Permutation not restored")
+	}
+}
+
+func TestCycleDecomposition(t *testing.T) {
+	cycles := CycleDecomposition([]int{2, 0, 1, 3, 5, 4})
+	truth := [][]int{{0, 2, 1}, {3}, {4, 5}}
+	if len(cycles) != len(truth) {
+		t.Fatalf("Wrong number of cycles %v", cycles)
+	}
+	for i := range truth {
+		AreSlicesEqual(t, truth[i], cycles[i], "Wrong cycle")
+	}
+}
+
+func TestNextPermutation(t *testing.T) {
+	s := []int{1, 2, 2, 3}
+	n := 1
+	for NextPermutation(s) {
+		n++
+	}
+	if n != 12 {
+		t.Errorf("Wrong number of distinct permutations %v, want 12", n)
+	}
+	AreSlicesEqual(t, []int{1, 2, 2, 3}, s, "Not reset to first permutation")
+
+	s = []int{1, 3, 2}
+	if !NextPermutation(s) {
+		t.Errorf("Reported last permutation early")
+	}
+	AreSlicesEqual(t, []int{2, 1, 3}, s, "Wrong next permutation")
+	if !PrevPermutation(s) {
+		t.Errorf("Reported first permutation early")
+	}
+	AreSlicesEqual(t, []int{1, 3, 2}, s, "Wrong previous permutation")
+
+	s = []int{1, 2, 3}
+	if PrevPermutation(s) {
+		t.Errorf("Did not report first permutation")
+	}
+	AreSlicesEqual(t, []int{3, 2, 1}, s, "Not reset to last permutation")
+	if NextPermutation(nil) {
+		t.Errorf("Empty slice has a next permutation")
+	}
+}
@@ -0,0 +1,189 @@
+package ints
+
+import (
+	"iter"
+	"math/bits"
+	"sort"
+)
+
+// Source is a source of uniformly distributed random 64-bit values. The
+// generators in this package implement Source, and their output for a
+// given seed will not change between releases.
+type Source interface {
+	Uint64() uint64
+}
+
+// SplitMix is the SplitMix64 generator of Steele, Lea and Flood. It is
+// fast and has a 64-bit state, which makes it suitable for seeding other
+// generators.
+type SplitMix struct {
+	state uint64
+}
+
+// NewSplitMix returns a SplitMix generator seeded with seed.
+func NewSplitMix(seed uint64) *SplitMix {
+	return &SplitMix{state: seed}
+}
+
+// Uint64 returns the next value of the generator.
+func (s *SplitMix) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// PCG is the PCG-XSH-RR generator of O'Neill with a 64-bit state and
+// 32-bit output. Generators seeded with different sequence numbers
+// produce independent streams.
+type PCG struct {
+	state, inc uint64
+}
+
+// NewPCG returns a PCG generator seeded with seed on stream seq.
+func NewPCG(seed, seq uint64) *PCG {
+	p := &PCG{inc: seq<<1 | 1}
+	p.Uint32()
+	p.state += seed
+	p.Uint32()
+	return p
+}
+
+// Uint32 returns the next 32-bit value of the generator.
+func (p *PCG) Uint32() uint32 {
+	old := p.state
+	p.state = old*6364136223846793005 + p.inc
+	xorshifted := uint32(((old >> 18) ^ old) >> 27)
+	rot := int(old >> 59)
+	return bits.RotateLeft32(xorshifted, -rot)
+}
+
+// Uint64 returns the next value of the generator, formed from two 32-bit
+// values with the first in the high bits.
+func (p *PCG) Uint64() uint64 {
+	hi := uint64(p.Uint32())
+	return hi<<32 | uint64(p.Uint32())
+}
+
+// uintn returns a uniformly distributed value in [0, n) using Lemire's
+// multiply and reject method. n must be positive.
+func uintn(src Source, n uint64) uint64 {
+	hi, lo := bits.Mul64(src.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(src.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// Intn returns a uniformly distributed value in [0, n) drawn from src.
+// It panics if n <= 0.
+func Intn(src Source, n int) int {
+	if n <= 0 {
+		panic(invalidArgument("non-positive bound"))
+	}
+	return int(uintn(src, uint64(n)))
+}
+
+// FillUniform stores uniformly distributed values in [lo, hi] drawn from
+// src in the elements of s. It panics if lo > hi.
+func FillUniform(src Source, lo, hi int, s []int) {
+	if lo > hi {
+		panic(invalidArgument("lower bound exceeds upper bound"))
+	}
+	span := uint64(hi) - uint64(lo) + 1
+	for i := range s {
+		if span == 0 {
+			// The bounds cover every int.
+			s[i] = int(src.Uint64())
+			continue
+		}
+		s[i] = lo + int(uintn(src, span))
+	}
+}
+
+// Shuffle randomly permutes the elements of s using the Fisher-Yates
+// algorithm with values drawn from src.
+func Shuffle(src Source, s []int) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := int(uintn(src, uint64(i+1)))
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Sample stores len(dst) distinct values chosen uniformly from [0, n) in
+// dst, in increasing order, using Floyd's algorithm. It panics if
+// len(dst) > n.
+func Sample(dst []int, src Source, n int) []int {
+	k := len(dst)
+	if k > n {
+		panic(invalidArgument("sample larger than population"))
+	}
+	chosen := make(map[int]struct{}, k)
+	for j, i := n-k, 0; j < n; j, i = j+1, i+1 {
+		t := int(uintn(src, uint64(j+1)))
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		dst[i] = t
+	}
+	sort.Ints(dst)
+	return dst
+}
+
+// Reservoir maintains a uniform random sample of fixed size from a stream
+// of values of unknown length.
+type Reservoir struct {
+	src    Source
+	sample []int
+	k      int
+	seen   int
+}
+
+// NewReservoir returns a Reservoir holding a sample of up to k values
+// chosen with values drawn from src. It panics if k < 0.
+func NewReservoir(src Source, k int) *Reservoir {
+	if k < 0 {
+		panic(invalidArgument("negative sample size"))
+	}
+	return &Reservoir{src: src, sample: make([]int, 0, k), k: k}
+}
+
+// Add offers v to the reservoir.
+func (r *Reservoir) Add(v int) {
+	r.seen++
+	if len(r.sample) < r.k {
+		r.sample = append(r.sample, v)
+		return
+	}
+	if j := uintn(r.src, uint64(r.seen)); j < uint64(r.k) {
+		r.sample[j] = v
+	}
+}
+
+// Seen returns the number of values offered to the reservoir.
+func (r *Reservoir) Seen() int {
+	return r.seen
+}
+
+// Sample returns the current sample, which holds min(k, Seen()) values.
+// The returned slice is owned by the reservoir and is changed by Add.
+func (r *Reservoir) Sample() []int {
+	return r.sample
+}
+
+// SampleSeq stores a uniform random sample of len(dst) values produced by
+// seq in dst using reservoir sampling with values drawn from src. If seq
+// produces fewer values than len(dst), all of them are stored and the
+// shortened dst is returned.
+func SampleSeq(dst []int, src Source, seq iter.Seq[int]) []int {
+	r := Reservoir{src: src, sample: dst[:0], k: len(dst)}
+	for v := range seq {
+		r.Add(v)
+	}
+	return r.sample
+}
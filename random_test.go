@@ -0,0 +1,131 @@
+package ints
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSplitMix(t *testing.T) {
+	// Reference values of the SplitMix64 generator.
+	src := NewSplitMix(1234567)
+	for i, want := range []uint64{
+		6457827717110365317,
+		3203168211198807973,
+		9817491932198370423,
+		4593380528125082431,
+		16408922859458223821,
+	} {
+		if got := src.Uint64(); got != want {
+			t.Errorf("Value %v: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestPCG(t *testing.T) {
+	// Reference values of pcg32 seeded with state 42 on sequence 54.
+	src := NewPCG(42, 54)
+	for i, want := range []uint32{0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e} {
+		if got := src.Uint32(); got != want {
+			t.Errorf("Value %v: got %#x, want %#x", i, got, want)
+		}
+	}
+	src = NewPCG(42, 54)
+	if got := src.Uint64(); got != 0xa15c02b77b47f409 {
+		t.Errorf("Wrong Uint64 %#x", got)
+	}
+}
+
+func TestFillUniform(t *testing.T) {
+	src := NewSplitMix(1)
+	s := make([]int, 10000)
+	FillUniform(src, -3, 3, s)
+	counts := make([]int, 7)
+	for _, v := range s {
+		if v < -3 || v > 3 {
+			t.Fatalf("Value %v out of range", v)
+		}
+		counts[v+3]++
+	}
+	for i, c := range counts {
+		if c < 1200 || c > 1650 {
+			t.Errorf("Value %v drawn %v times", i-3, c)
+		}
+	}
+	FillUniform(src, math.MinInt, math.MaxInt, s)
+	FillUniform(src, 5, 5, s)
+	if Count(func(v int) bool { return v == 5 }, s) != len(s) {
+		t.Errorf("Degenerate range not respected")
+	}
+	if !Panics(func() { FillUniform(src, 1, 0, s) }) {
+		t.Errorf("Did not panic with empty range")
+	}
+	if !Panics(func() { Intn(src, 0) }) {
+		t.Errorf("Intn did not panic with zero bound")
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	Shuffle(NewSplitMix(7), s)
+	if !IsPermutation(s) {
+		t.Errorf("Shuffle lost elements: %v", s)
+	}
+	s2 := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	Shuffle(NewSplitMix(7), s2)
+	AreSlicesEqual(t, s, s2, "Shuffle not deterministic")
+}
+
+func TestSample(t *testing.T) {
+	src := NewPCG(1, 1)
+	counts := make([]int, 10)
+	dst := make([]int, 3)
+	for i := 0; i < 5000; i++ {
+		Sample(dst, src, 10)
+		if !slices.IsSorted(dst) || dst[0] == dst[1] || dst[1] == dst[2] {
+			t.Fatalf("Sample not distinct and sorted: %v", dst)
+		}
+		for _, v := range dst {
+			counts[v]++
+		}
+	}
+	for i, c := range counts {
+		if c < 1350 || c > 1650 {
+			t.Errorf("Value %v sampled %v times", i, c)
+		}
+	}
+	all := Sample(make([]int, 4), src, 4)
+	AreSlicesEqual(t, []int{0, 1, 2, 3}, all, "Full sample")
+	if !Panics(func() { Sample(make([]int, 5), src, 4) }) {
+		t.Errorf("Did not panic with sample larger than population")
+	}
+}
+
+func TestReservoir(t *testing.T) {
+	src := NewSplitMix(3)
+	counts := make([]int, 20)
+	for i := 0; i < 4000; i++ {
+		r := NewReservoir(src, 5)
+		for v := 0; v < 20; v++ {
+			r.Add(v)
+		}
+		if r.Seen() != 20 || len(r.Sample()) != 5 {
+			t.Fatalf("Wrong reservoir state")
+		}
+		for _, v := range r.Sample() {
+			counts[v]++
+		}
+	}
+	for i, c := range counts {
+		if c < 850 || c > 1150 {
+			t.Errorf("Value %v sampled %v times", i, c)
+		}
+	}
+
+	got := SampleSeq(make([]int, 5), src, slices.Values([]int{4, 8}))
+	AreSlicesEqual(t, []int{4, 8}, got, "Short stream")
+	got = SampleSeq(make([]int, 2), src, slices.Values([]int{1, 2, 3, 4}))
+	if len(got) != 2 {
+		t.Errorf("Wrong sample length %v", len(got))
+	}
+}
@@ -0,0 +1,193 @@
+package ints
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxRangeValues is the largest number of values ParseRanges returns. The
+// count of each item is known from its bounds, so input exceeding it is
+// rejected before any value is generated.
+const MaxRangeValues = 1 << 20
+
+// ParseRanges parses a comma-separated list of values and inclusive ranges
+// such as "1-5,7,9-12" and returns the values in increasing order. A range
+// may carry a step, so that "1-10:3" is 1, 4, 7, 10; the upper bound need
+// not be reached by the step. Negative values are written with a leading
+// minus sign, as in "-5--2". Items may appear in any order, but a value
+// given more than once is an error, as is a list of more than
+// MaxRangeValues values. Errors are of type *ParseError with Column set to
+// the position of the offending item.
+func ParseRanges(s string) ([]int, error) {
+	if strings.TrimSpace(s) == "" {
+		return []int{}, nil
+	}
+	var items []rangeItem
+	var total uint64
+	pos := 0
+	for _, text := range strings.Split(s, ",") {
+		col := pos + 1
+		pos += len(text) + 1
+		trimmed := strings.TrimLeft(text, " \t")
+		col += len(text) - len(trimmed)
+		trimmed = strings.TrimRight(trimmed, " \t")
+		item, err := parseRangeItem(trimmed)
+		if err == nil && item.n > MaxRangeValues-total {
+			err = invalidArgument(fmt.Sprintf("more than %d values", MaxRangeValues))
+		}
+		if err != nil {
+			return nil, &ParseError{Line: 1, Column: col, Text: trimmed, Err: err}
+		}
+		item.col = col
+		items = append(items, item)
+		total += item.n
+	}
+
+	// Generate the values tagged with their item, so that a value given
+	// more than once is found next to its duplicate once sorted.
+	tagged := make([]rangeValue, 0, total)
+	for i, item := range items {
+		for k, v := uint64(0), item.lo; k < item.n; k, v = k+1, v+item.step {
+			tagged = append(tagged, rangeValue{v: v, item: i})
+		}
+	}
+	sort.Slice(tagged, func(i, j int) bool {
+		if tagged[i].v != tagged[j].v {
+			return tagged[i].v < tagged[j].v
+		}
+		return tagged[i].item < tagged[j].item
+	})
+	dst := make([]int, len(tagged))
+	for i, t := range tagged {
+		if i > 0 && t.v == tagged[i-1].v {
+			return nil, &ParseError{Line: 1, Column: max(items[t.item].col, items[tagged[i-1].item].col),
+				Text: strconv.Itoa(t.v), Err: malformed("value appears in more than one item")}
+		}
+		dst[i] = t.v
+	}
+	return dst, nil
+}
+
+// rangeItem is a single item of a range list: n values starting at lo and
+// separated by step.
+type rangeItem struct {
+	lo, step int
+	n        uint64
+	col      int
+}
+
+// rangeValue is a value of a range list and the index of its item.
+type rangeValue struct {
+	v, item int
+}
+
+// parseRangeItem parses a single item without generating its values.
+func parseRangeItem(item string) (rangeItem, error) {
+	if item == "" {
+		return rangeItem{}, ErrEmptyField
+	}
+	lo, rest, err := cutRangeInt(item)
+	if err != nil {
+		return rangeItem{}, err
+	}
+	if rest == "" {
+		return rangeItem{lo: lo, step: 1, n: 1}, nil
+	}
+	if rest[0] != '-' {
+		return rangeItem{}, strconv.ErrSyntax
+	}
+	hi, rest, err := cutRangeInt(rest[1:])
+	if err != nil {
+		return rangeItem{}, err
+	}
+	step := 1
+	if rest != "" {
+		if rest[0] != ':' {
+			return rangeItem{}, strconv.ErrSyntax
+		}
+		step, err = strconv.Atoi(rest[1:])
+		if err != nil {
+			return rangeItem{}, err.(*strconv.NumError).Err
+		}
+		if step <= 0 || rest[1] == '+' {
+			return rangeItem{}, malformed("step must be a positive integer")
+		}
+	}
+	if lo > hi {
+		return rangeItem{}, malformed("lower bound exceeds upper bound")
+	}
+	// Count as unsigned so that bounds near the limits of int do not
+	// overflow. The count itself may not fit when the step is 1, so it is
+	// capped before the first value is added.
+	n := (uint64(hi) - uint64(lo)) / uint64(step)
+	if n >= MaxRangeValues {
+		return rangeItem{}, invalidArgument(fmt.Sprintf("more than %d values", MaxRangeValues))
+	}
+	return rangeItem{lo: lo, step: step, n: n + 1}, nil
+}
+
+// cutRangeInt parses the integer, with an optional leading minus sign, at
+// the start of s and returns it along with the remainder of s.
+func cutRangeInt(s string) (int, string, error) {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	start := i
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == start {
+		return 0, s, strconv.ErrSyntax
+	}
+	v, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return 0, s, err.(*strconv.NumError).Err
+	}
+	return v, s[i:], nil
+}
+
+// FormatRanges returns the compact form of s parsed by ParseRanges. Runs of
+// three or more values with a common difference are written as ranges,
+// with a step when the difference is not 1. FormatRanges panics if s is
+// not strictly increasing.
+func FormatRanges(s []int) string {
+	return string(AppendRanges(nil, s))
+}
+
+// AppendRanges appends the compact form of s, as generated by FormatRanges,
+// to dst and returns the extended buffer.
+func AppendRanges(dst []byte, s []int) []byte {
+	for i := 1; i < len(s); i++ {
+		if s[i] <= s[i-1] {
+			panic(invalidArgument("slice is not strictly increasing"))
+		}
+	}
+	for i := 0; i < len(s); {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = strconv.AppendInt(dst, int64(s[i]), 10)
+		j := i + 1
+		if j < len(s) {
+			step := s[j] - s[i]
+			for j+1 < len(s) && s[j+1]-s[j] == step {
+				j++
+			}
+			if j-i >= 2 && step > 0 {
+				dst = append(dst, '-')
+				dst = strconv.AppendInt(dst, int64(s[j]), 10)
+				if step != 1 {
+					dst = append(dst, ':')
+					dst = strconv.AppendInt(dst, int64(step), 10)
+				}
+				i = j + 1
+				continue
+			}
+		}
+		i++
+	}
+	return dst
+}
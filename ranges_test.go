@@ -0,0 +1,163 @@
+package ints
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestParseRanges(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want []int
+	}{
+		{"", []int{}},
+		{"7", []int{7}},
+		{"1-5,7,9-12", []int{1, 2, 3, 4, 5, 7, 9, 10, 11, 12}},
+		{" 9-10 , 1-3 ", []int{1, 2, 3, 9, 10}},
+		{"1-20:3", []int{1, 4, 7, 10, 13, 16, 19}},
+		{"1-10:2,2-10:2", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{"-5--3,-1-1", []int{-5, -4, -3, -1, 0, 1}},
+		{"9223372036854775806-9223372036854775807", []int{math.MaxInt64 - 1, math.MaxInt64}},
+		{"-9223372036854775808--9223372036854775808:5", []int{math.MinInt64}},
+	} {
+		got, err := ParseRanges(test.in)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.in, err)
+			continue
+		}
+		AreSlicesEqual(t, test.want, got, "Parsing "+test.in)
+	}
+}
+
+func TestParseRangesErrors(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		col int
+	}{
+		{"1,,3", 3},
+		{"1, x", 4},
+		{"1-", 1},
+		{"5-1", 1},
+		{"1-5:0", 1},
+		{"1-5:-1", 1},
+		{"1:2", 1},
+		{"1-5,3", 5},
+		{"1-10:2, 9-12", 9},
+		{"99999999999999999999", 1},
+		{"0-9223372036854775807", 1},
+		{"-9223372036854775808-9223372036854775807", 1},
+		{"1, 0-1048575", 4},
+		{"0-1048575, 2000000", 12},
+		{"3-30:6, 1-40:4", 9},
+	} {
+		_, err := ParseRanges(test.in)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%q: expected a *ParseError, got %v", test.in, err)
+			continue
+		}
+		if perr.Column != test.col {
+			t.Errorf("%q: error at column %v, want %v: %v", test.in, perr.Column, test.col, err)
+		}
+	}
+}
+
+func TestParseRangesSentinels(t *testing.T) {
+	for _, in := range []string{"5-1", "1-5:0", "1-5,3"} {
+		if _, err := ParseRanges(in); !errors.Is(err, ErrMalformed) {
+			t.Errorf("%q: expected an error wrapping ErrMalformed, got %v", in, err)
+		}
+	}
+	if _, err := ParseRanges("1,,2"); !errors.Is(err, ErrEmptyField) {
+		t.Errorf("Empty item returned %v", err)
+	}
+}
+
+func TestParseRangesLimit(t *testing.T) {
+	got, err := ParseRanges("0-1048575")
+	if err != nil || len(got) != MaxRangeValues {
+		t.Errorf("Parsing MaxRangeValues values returned %v values, %v", len(got), err)
+	}
+	_, err = ParseRanges("0-9223372036854775807")
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Parsing a huge range returned %v", err)
+	}
+}
+
+func TestParseRangesOverlap(t *testing.T) {
+	// Compare overlap detection from the bounds with the generated values.
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 2000; n++ {
+		var items []string
+		seen := make(map[int]bool)
+		dup := false
+		for k := 0; k < 2; k++ {
+			lo, step := rnd.Intn(40)-20, rnd.Intn(7)+1
+			hi := lo + rnd.Intn(40)
+			items = append(items, fmt.Sprintf("%d-%d:%d", lo, hi, step))
+			for v := lo; v <= hi; v += step {
+				dup = dup || seen[v]
+				seen[v] = true
+			}
+		}
+		in := strings.Join(items, ",")
+		_, err := ParseRanges(in)
+		if dup != (err != nil) {
+			t.Errorf("%q: overlap %v, got error %v", in, dup, err)
+		}
+	}
+}
+
+func TestParseRangesManyOverlapping(t *testing.T) {
+	// Items of two values whose bounds all overlap must not make the
+	// overlap check quadratic in the number of items.
+	var items []string
+	for k := 0; k < 20000; k++ {
+		items = append(items, fmt.Sprintf("%d-%d:%d", k, k+1e18, int64(1e18)))
+	}
+	got, err := ParseRanges(strings.Join(items, ","))
+	if err != nil || len(got) != 40000 {
+		t.Errorf("Parsing returned %v values, %v", len(got), err)
+	}
+	items = append(items, "19999")
+	if _, err := ParseRanges(strings.Join(items, ",")); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Duplicate value returned %v", err)
+	}
+}
+
+func TestFormatRanges(t *testing.T) {
+	for _, test := range []struct {
+		s    []int
+		want string
+	}{
+		{nil, ""},
+		{[]int{4}, "4"},
+		{[]int{1, 2}, "1,2"},
+		{[]int{1, 2, 3, 4, 5, 7, 9, 10, 11, 12}, "1-5,7,9-12"},
+		{[]int{1, 4, 7, 10, 11}, "1-10:3,11"},
+		{[]int{-5, -4, -3, 0}, "-5--3,0"},
+		{[]int{math.MinInt64, 0, math.MaxInt64}, "-9223372036854775808,0,9223372036854775807"},
+	} {
+		got := FormatRanges(test.s)
+		if got != test.want {
+			t.Errorf("FormatRanges(%v) = %q, want %q", test.s, got, test.want)
+		}
+		back, err := ParseRanges(got)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", got, err)
+		}
+		if len(test.s) > 0 {
+			AreSlicesEqual(t, test.s, back, "Round trip of "+got)
+		}
+	}
+	if !Panics(func() { FormatRanges([]int{1, 3, 2}) }) {
+		t.Errorf("Did not panic with unsorted input")
+	}
+	if !Panics(func() { FormatRanges([]int{1, 1}) }) {
+		t.Errorf("Did not panic with repeated values")
+	}
+}
@@ -0,0 +1,76 @@
+package ints
+
+// RunLengthEncode splits s into runs of equal elements. The value of each
+// run is appended to values[:0] and its length to lengths[:0], and the
+// extended slices are returned. Neither values nor lengths may share data
+// with s.
+func RunLengthEncode(values, lengths, s []int) ([]int, []int) {
+	values, lengths = values[:0], lengths[:0]
+	for i := 0; i < len(s); {
+		j := i + 1
+		for j < len(s) && s[j] == s[i] {
+			j++
+		}
+		values = append(values, s[i])
+		lengths = append(lengths, j-i)
+		i = j
+	}
+	return values, lengths
+}
+
+// RunLengthDecode expands the runs given by values and lengths, as
+// generated by RunLengthEncode, into dst[:0] and returns the extended
+// slice. Runs of length zero are allowed.
+// A panic will occur if the lengths of values and lengths do not match or
+// if a run length is negative.
+func RunLengthDecode(dst, values, lengths []int) []int {
+	checkRLE("RunLengthDecode", values, lengths)
+	dst = dst[:0]
+	for i, v := range values {
+		for n := lengths[i]; n > 0; n-- {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// SumRLE returns the sum of the elements of the run-length encoded slice
+// given by values and lengths, without expanding it.
+// A panic will occur if the lengths of values and lengths do not match or
+// if a run length is negative.
+func SumRLE(values, lengths []int) (sum int) {
+	checkRLE("SumRLE", values, lengths)
+	for i, v := range values {
+		sum += v * lengths[i]
+	}
+	return sum
+}
+
+// IndexRLE returns element i of the run-length encoded slice given by
+// values and lengths, without expanding it. The cost is linear in the
+// number of runs.
+// A panic will occur if the lengths of values and lengths do not match, if
+// a run length is negative or if i is out of range.
+func IndexRLE(values, lengths []int, i int) int {
+	checkRLE("IndexRLE", values, lengths)
+	if i >= 0 {
+		for j, n := range lengths {
+			if i < n {
+				return values[j]
+			}
+			i -= n
+		}
+	}
+	panic(ErrIndexOutOfRange)
+}
+
+func checkRLE(fn string, values, lengths []int) {
+	if len(values) != len(lengths) {
+		panic(lengthError(fn, "values, lengths", len(values), len(lengths)))
+	}
+	for _, n := range lengths {
+		if n < 0 {
+			panic(invalidArgument("negative run length"))
+		}
+	}
+}
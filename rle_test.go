@@ -0,0 +1,46 @@
+package ints
+
+import "testing"
+
+func TestRunLengthEncode(t *testing.T) {
+	s := []int{3, 3, 3, 1, 4, 4, 1, 1}
+	values, lengths := RunLengthEncode(nil, nil, s)
+	AreSlicesEqual(t, []int{3, 1, 4, 1}, values, "Run values")
+	AreSlicesEqual(t, []int{3, 1, 2, 2}, lengths, "Run lengths")
+	AreSlicesEqual(t, s, RunLengthDecode(nil, values, lengths), "Round trip")
+
+	values, lengths = RunLengthEncode(values, lengths, nil)
+	if len(values) != 0 || len(lengths) != 0 {
+		t.Errorf("Runs of empty slice: %v, %v", values, lengths)
+	}
+	AreSlicesEqual(t, []int{7, 7, 9}, RunLengthDecode(make([]int, 5), []int{7, 8, 9}, []int{2, 0, 1}), "Zero length run")
+	if !Panics(func() { RunLengthDecode(nil, []int{1}, []int{-1}) }) {
+		t.Errorf("Did not panic with negative run length")
+	}
+	if !Panics(func() { RunLengthDecode(nil, []int{1, 2}, []int{1}) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestSumRLE(t *testing.T) {
+	s := []int{-2, -2, 5, 5, 5, 0, 1}
+	values, lengths := RunLengthEncode(nil, nil, s)
+	if got, want := SumRLE(values, lengths), Sum(s); got != want {
+		t.Errorf("SumRLE = %v, want %v", got, want)
+	}
+}
+
+func TestIndexRLE(t *testing.T) {
+	s := []int{-2, -2, 5, 5, 5, 0, 1}
+	values, lengths := RunLengthEncode(nil, nil, s)
+	for i, want := range s {
+		if got := IndexRLE(values, lengths, i); got != want {
+			t.Errorf("IndexRLE(%v) = %v, want %v", i, got, want)
+		}
+	}
+	for _, i := range []int{-1, len(s)} {
+		if !Panics(func() { IndexRLE(values, lengths, i) }) {
+			t.Errorf("Did not panic with index %v", i)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package ints
+
+import "math"
+
+// Align selects the position of the window of a rolling aggregation
+// relative to the element of the destination it is stored in.
+type Align int
+
+const (
+	// Trailing windows end at element i, covering s[i-w+1 : i+1].
+	Trailing Align = iota
+	// Centered windows are centered on element i, covering
+	// s[i-(w-1)/2 : i+w/2+1]. Even windows extend further forward.
+	Centered
+)
+
+// window returns the bounds [lo, hi) of the window of width w for element
+// i of a slice of length n. Windows are truncated at the ends of the slice.
+func window(i, n, w int, align Align) (lo, hi int) {
+	switch align {
+	case Trailing:
+		return max(0, i-w+1), i + 1
+	case Centered:
+		return max(0, i-(w-1)/2), min(n, i+w/2+1)
+	}
+	panic(invalidArgument("unknown window alignment"))
+}
+
+// checkRolling panics if the arguments of a rolling aggregation are
+// invalid.
+func checkRolling(fn string, dst, inds, s []int, w int) {
+	if len(dst) != len(s) {
+		panic(lengthError(fn, "dst, s", len(dst), len(s)))
+	}
+	if inds != nil && len(inds) != len(s) {
+		panic(lengthError(fn, "inds, s", len(inds), len(s)))
+	}
+	if w < 1 {
+		panic(invalidArgument("window width must be positive"))
+	}
+}
+
+// RollingSum stores in dst[i] the sum of the window of width w for element
+// i of s, as selected by align. Windows are truncated at the ends of s.
+// The sums are maintained as running totals, so the cost does not depend
+// on w. dst must not share data with s.
+// A panic will occur if lengths of arguments do not match or if w < 1.
+func RollingSum(dst, s []int, w int, align Align) []int {
+	checkRolling("RollingSum", dst, nil, s, w)
+	var sum, lo, hi int
+	for i := range dst {
+		l, h := window(i, len(s), w, align)
+		for ; hi < h; hi++ {
+			sum += s[hi]
+		}
+		for ; lo < l; lo++ {
+			sum -= s[lo]
+		}
+		dst[i] = sum
+	}
+	return dst
+}
+
+// RollingCount stores in dst[i] the number of elements of the window of
+// width w for element i of s, as selected by align, for which f returns
+// true. f is called once for each element of s. Windows are truncated at
+// the ends of s. dst must not share data with s.
+// A panic will occur if lengths of arguments do not match or if w < 1.
+func RollingCount(dst []int, f func(int) bool, s []int, w int, align Align) []int {
+	checkRolling("RollingCount", dst, nil, s, w)
+	// The window leading edge runs ahead of i by at most w, so a ring of
+	// the last w results of f is enough to remove elements as they leave.
+	hits := make([]bool, min(w, len(s))+1)
+	var n, lo, hi int
+	for i := range dst {
+		l, h := window(i, len(s), w, align)
+		for ; hi < h; hi++ {
+			hit := f(s[hi])
+			hits[hi%len(hits)] = hit
+			if hit {
+				n++
+			}
+		}
+		for ; lo < l; lo++ {
+			if hits[lo%len(hits)] {
+				n--
+			}
+		}
+		dst[i] = n
+	}
+	return dst
+}
+
+// RollingMax stores in dst[i] the maximum of the window of width w for
+// element i of s, as selected by align, and the index in s of the maximum
+// in inds[i]. As with Max, the lowest index is reported when the maximum
+// occurs more than once. inds may be nil. Windows are truncated at the
+// ends of s. dst must not share data with s.
+// A panic will occur if lengths of arguments do not match or if w < 1.
+func RollingMax(dst, inds, s []int, w int, align Align) []int {
+	checkRolling("RollingMax", dst, inds, s, w)
+	rollingExtreme(dst, inds, s, w, align, func(a, b int) bool { return a > b })
+	return dst
+}
+
+// RollingMin stores in dst[i] the minimum of the window of width w for
+// element i of s, as selected by align, and the index in s of the minimum
+// in inds[i]. As with Min, the lowest index is reported when the minimum
+// occurs more than once. inds may be nil. Windows are truncated at the
+// ends of s. dst must not share data with s.
+// A panic will occur if lengths of arguments do not match or if w < 1.
+func RollingMin(dst, inds, s []int, w int, align Align) []int {
+	checkRolling("RollingMin", dst, inds, s, w)
+	rollingExtreme(dst, inds, s, w, align, func(a, b int) bool { return a < b })
+	return dst
+}
+
+// rollingExtreme computes the rolling extreme under better using a
+// monotonic deque of indices. The values at the indices in the deque are
+// ordered so that the front holds the extreme of the window. An index is
+// only dropped from the back when a strictly better value arrives, so the
+// front is the earliest index among equal extremes.
+func rollingExtreme(dst, inds, s []int, w int, align Align, better func(a, b int) bool) {
+	ring := make([]int, min(w, len(s))+1)
+	var head, size, hi int
+	for i := range dst {
+		l, h := window(i, len(s), w, align)
+		for ; hi < h; hi++ {
+			for size > 0 && better(s[hi], s[ring[(head+size-1)%len(ring)]]) {
+				size--
+			}
+			ring[(head+size)%len(ring)] = hi
+			size++
+		}
+		for ring[head] < l {
+			head = (head + 1) % len(ring)
+			size--
+		}
+		dst[i] = s[ring[head]]
+		if inds != nil {
+			inds[i] = ring[head]
+		}
+	}
+}
+
+// RollingMedian stores in dst[i] the median of the window of width w for
+// element i of s, as selected by align. The median of a window with an
+// even number of elements is the lower of the two middle values, so that
+// the result is always an element of s. Each step costs O(log w).
+// Windows are truncated at the ends of s. dst must not share data with s.
+// A panic will occur if lengths of arguments do not match or if w < 1.
+func RollingMedian(dst, s []int, w int, align Align) []int {
+	checkRolling("RollingMedian", dst, nil, s, w)
+	rollingRank(dst, s, w, align, func(n int) int { return (n + 1) / 2 })
+	return dst
+}
+
+// RollingPercentile stores in dst[i] the p-th percentile of the window of
+// width w for element i of s, as selected by align, for p between 0 and
+// 100. The percentile is found by the nearest-rank method: it is the k-th
+// smallest element of the window, where k is p/100 times the window size
+// rounded up, and at least 1. A p of 50 gives the same results as
+// RollingMedian. Each step costs O(log w). Windows are truncated at the
+// ends of s. dst must not share data with s.
+// A panic will occur if lengths of arguments do not match, if w < 1 or if
+// p is outside [0, 100].
+func RollingPercentile(dst, s []int, p float64, w int, align Align) []int {
+	checkRolling("RollingPercentile", dst, nil, s, w)
+	if !(p >= 0 && p <= 100) {
+		panic(invalidArgument("percentile outside [0, 100]"))
+	}
+	rollingRank(dst, s, w, align, func(n int) int {
+		// p*n is exact for the window sizes of interest, so the rank is
+		// exact whenever p/100*n is an integer.
+		return max(1, int(math.Ceil(p*float64(n)/100)))
+	})
+	return dst
+}
+
+// rollingRank stores in dst[i] the rank(n)-th smallest element of the n
+// elements of window i. The window is split between a max-heap holding
+// its rank(n) smallest elements and a min-heap holding the rest, so that
+// the result is at the top of the max-heap. Elements leaving the window
+// are removed from whichever heap holds them.
+func rollingRank(dst, s []int, w int, align Align, rank func(n int) int) {
+	r := newRankWindow(s, min(w, len(s))+1)
+	var lo, hi int
+	for i := range dst {
+		l, h := window(i, len(s), w, align)
+		for ; hi < h; hi++ {
+			r.add(hi)
+		}
+		for ; lo < l; lo++ {
+			r.remove(lo)
+		}
+		r.balance(rank(hi - lo))
+		dst[i] = s[r.low.items[0]]
+	}
+}
+
+// rankWindow is a window of s split between two heaps such that every
+// element of low is less than or equal to every element of high.
+type rankWindow struct {
+	low, high windowHeap
+	// inLow records, for each index of s in the window, whether it is held
+	// by low. It is indexed modulo its length like pos.
+	inLow []bool
+}
+
+func newRankWindow(s []int, size int) *rankWindow {
+	pos := make([]int, size)
+	return &rankWindow{
+		low:   windowHeap{s: s, items: make([]int, 0, size), pos: pos, max: true},
+		high:  windowHeap{s: s, items: make([]int, 0, size), pos: pos},
+		inLow: make([]bool, size),
+	}
+}
+
+// add inserts index i of s into the window.
+func (r *rankWindow) add(i int) {
+	inLow := len(r.high.items) == 0 || r.low.s[i] <= r.low.s[r.high.items[0]]
+	r.inLow[i%len(r.inLow)] = inLow
+	if inLow {
+		r.low.push(i)
+	} else {
+		r.high.push(i)
+	}
+}
+
+// remove deletes index i of s from the window.
+func (r *rankWindow) remove(i int) {
+	if r.inLow[i%len(r.inLow)] {
+		r.low.remove(r.low.pos[i%len(r.low.pos)])
+	} else {
+		r.high.remove(r.high.pos[i%len(r.high.pos)])
+	}
+}
+
+// balance moves elements between the heaps until low holds k elements.
+func (r *rankWindow) balance(k int) {
+	for len(r.low.items) > k {
+		i := r.low.remove(0)
+		r.inLow[i%len(r.inLow)] = false
+		r.high.push(i)
+	}
+	for len(r.low.items) < k {
+		i := r.high.remove(0)
+		r.inLow[i%len(r.inLow)] = true
+		r.low.push(i)
+	}
+}
+
+// windowHeap is a binary heap of indices of s ordered by their values. The
+// position in items of each index is kept in pos, modulo its length, so
+// that any element can be removed.
+type windowHeap struct {
+	s     []int
+	items []int
+	pos   []int
+	max   bool
+}
+
+func (h *windowHeap) above(a, b int) bool {
+	if h.max {
+		return h.s[h.items[a]] > h.s[h.items[b]]
+	}
+	return h.s[h.items[a]] < h.s[h.items[b]]
+}
+
+func (h *windowHeap) swap(a, b int) {
+	h.items[a], h.items[b] = h.items[b], h.items[a]
+	h.pos[h.items[a]%len(h.pos)] = a
+	h.pos[h.items[b]%len(h.pos)] = b
+}
+
+func (h *windowHeap) push(i int) {
+	h.items = append(h.items, i)
+	h.pos[i%len(h.pos)] = len(h.items) - 1
+	h.up(len(h.items) - 1)
+}
+
+// remove deletes the element at position p of the heap and returns it.
+func (h *windowHeap) remove(p int) int {
+	i := h.items[p]
+	last := len(h.items) - 1
+	if p != last {
+		h.swap(p, last)
+	}
+	h.items = h.items[:last]
+	if p != last {
+		h.down(p)
+		h.up(p)
+	}
+	return i
+}
+
+func (h *windowHeap) up(p int) {
+	for p > 0 {
+		parent := (p - 1) / 2
+		if !h.above(p, parent) {
+			return
+		}
+		h.swap(p, parent)
+		p = parent
+	}
+}
+
+func (h *windowHeap) down(p int) {
+	n := len(h.items)
+	for {
+		c := 2*p + 1
+		if c >= n {
+			return
+		}
+		if c+1 < n && h.above(c+1, c) {
+			c++
+		}
+		if !h.above(c, p) {
+			return
+		}
+		h.swap(p, c)
+		p = c
+	}
+}
@@ -0,0 +1,144 @@
+package ints
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// naiveRolling computes a rolling aggregation by calling f on every window.
+func naiveRolling(s []int, w int, align Align, f func(win []int, lo int) (int, int)) (vals, inds []int) {
+	vals, inds = make([]int, len(s)), make([]int, len(s))
+	for i := range s {
+		lo, hi := window(i, len(s), w, align)
+		vals[i], inds[i] = f(s[lo:hi], lo)
+	}
+	return vals, inds
+}
+
+func TestWindow(t *testing.T) {
+	for _, test := range []struct {
+		i, n, w int
+		align   Align
+		lo, hi  int
+	}{
+		{0, 10, 3, Trailing, 0, 1},
+		{5, 10, 3, Trailing, 3, 6},
+		{5, 10, 3, Centered, 4, 7},
+		{5, 10, 4, Centered, 4, 8},
+		{9, 10, 4, Centered, 8, 10},
+		{0, 10, 5, Centered, 0, 3},
+	} {
+		lo, hi := window(test.i, test.n, test.w, test.align)
+		if lo != test.lo || hi != test.hi {
+			t.Errorf("window(%v, %v, %v, %v) = [%v, %v), want [%v, %v)", test.i, test.n, test.w, test.align, lo, hi, test.lo, test.hi)
+		}
+	}
+}
+
+func TestRollingSum(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	dst := make([]int, len(s))
+	AreSlicesEqual(t, []int{3, 7, 8, 12, 13}, RollingSum(dst, s, 3, Trailing), "Trailing sum")
+	AreSlicesEqual(t, []int{7, 8, 12, 13, 12}, RollingSum(dst, s, 3, Centered), "Centered sum")
+	AreSlicesEqual(t, s, RollingSum(dst, s, 1, Centered), "Unit window")
+	AreSlicesEqual(t, CumSum(make([]int, len(s)), s), RollingSum(dst, s, 10, Trailing), "Wide window")
+	if !Panics(func() { RollingSum(dst, s, 0, Trailing) }) {
+		t.Errorf("Did not panic with zero width")
+	}
+	if !Panics(func() { RollingSum(make([]int, 2), s, 2, Trailing) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestRollingCount(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5, 2, 8}
+	f := func(v int) bool { return v > 3 }
+	for _, align := range []Align{Trailing, Centered} {
+		for w := 1; w <= 8; w++ {
+			truth, _ := naiveRolling(s, w, align, func(win []int, lo int) (int, int) { return Count(f, win), 0 })
+			AreSlicesEqual(t, truth, RollingCount(make([]int, len(s)), f, s, w, align), "Rolling count")
+		}
+	}
+}
+
+func TestRollingMinMax(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	s := make([]int, 200)
+	for i := range s {
+		// Few distinct values so that ties are common.
+		s[i] = rnd.Intn(5)
+	}
+	for _, align := range []Align{Trailing, Centered} {
+		for _, w := range []int{1, 2, 3, 4, 7, 50, 300} {
+			maxTruth, maxInds := naiveRolling(s, w, align, func(win []int, lo int) (int, int) {
+				v, i := Max(win)
+				return v, lo + i
+			})
+			minTruth, minInds := naiveRolling(s, w, align, func(win []int, lo int) (int, int) {
+				v, i := Min(win)
+				return v, lo + i
+			})
+			dst, inds := make([]int, len(s)), make([]int, len(s))
+			RollingMax(dst, inds, s, w, align)
+			AreSlicesEqual(t, maxTruth, dst, "Rolling max")
+			AreSlicesEqual(t, maxInds, inds, "Rolling argmax")
+			RollingMin(dst, inds, s, w, align)
+			AreSlicesEqual(t, minTruth, dst, "Rolling min")
+			AreSlicesEqual(t, minInds, inds, "Rolling argmin")
+		}
+	}
+	RollingMin(make([]int, 3), nil, []int{3, 1, 2}, 2, Trailing)
+	if !Panics(func() { RollingMax(make([]int, 3), make([]int, 2), []int{3, 1, 2}, 2, Trailing) }) {
+		t.Errorf("Did not panic with inds length mismatch")
+	}
+}
+
+func TestRollingPercentile(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	s := make([]int, 150)
+	for i := range s {
+		s[i] = rnd.Intn(20) - 10
+	}
+	for _, align := range []Align{Trailing, Centered} {
+		for _, w := range []int{1, 2, 3, 6, 25, 200} {
+			for _, p := range []float64{0, 10, 25, 50, 70, 99, 100} {
+				truth, _ := naiveRolling(s, w, align, func(win []int, lo int) (int, int) {
+					sorted := append([]int(nil), win...)
+					sort.Ints(sorted)
+					k := int(math.Ceil(p / 100 * float64(len(sorted))))
+					return sorted[max(k, 1)-1], 0
+				})
+				got := RollingPercentile(make([]int, len(s)), s, p, w, align)
+				AreSlicesEqual(t, truth, got, "Rolling percentile")
+			}
+		}
+	}
+	if !Panics(func() { RollingPercentile(make([]int, 3), []int{1, 2, 3}, 101, 2, Trailing) }) {
+		t.Errorf("Did not panic with percentile above 100")
+	}
+	if !Panics(func() { RollingPercentile(make([]int, 3), []int{1, 2, 3}, math.NaN(), 2, Trailing) }) {
+		t.Errorf("Did not panic with NaN percentile")
+	}
+}
+
+func TestRollingMedian(t *testing.T) {
+	s := []int{5, 1, 4, 2, 8, 8, 3}
+	dst := make([]int, len(s))
+	AreSlicesEqual(t, []int{5, 1, 4, 2, 4, 8, 8}, RollingMedian(dst, s, 3, Trailing), "Trailing median")
+	AreSlicesEqual(t, []int{1, 4, 2, 4, 8, 8, 3}, RollingMedian(dst, s, 3, Centered), "Centered median")
+	AreSlicesEqual(t, RollingPercentile(make([]int, len(s)), s, 50, 4, Centered), RollingMedian(dst, s, 4, Centered), "Median and 50th percentile")
+}
+
+func BenchmarkRollingMedian(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	s := make([]int, 100000)
+	for i := range s {
+		s[i] = rnd.Int()
+	}
+	dst := make([]int, len(s))
+	for i := 0; i < b.N; i++ {
+		RollingMedian(dst, s, 101, Centered)
+	}
+}
@@ -0,0 +1,284 @@
+// Package safe mirrors the core slice routines of package ints, returning
+// errors instead of panicking on length mismatches, empty inputs, zero
+// divisors and invalid arguments. Arguments are validated before any work
+// is done, so no slice is modified when an error is returned.
+//
+// The functions mirrored are exactly those of the core routines that can
+// panic: Add, AddConstTo, AddScaled, AddScaledTo, ApplyIndexed, ApplyTo,
+// ApplyTo2, Argsort, CumProd, CumSum, Diff, Div, DivTo, Dot, Max, Min,
+// Mul, MulTo, ScaleTo, Span, Sub and SubTo. The routines of package ints
+// that cannot fail are not repeated here, and neither are its specialized
+// facilities, such as the codecs, bitsets, matrices, vectors, permutations,
+// scans, rolling windows, gathers, masks and element-wise comparisons,
+// which document the panics they raise.
+package safe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zacg/ints"
+)
+
+// LengthError is returned when the slices passed to a function do not have
+// the lengths it requires. It is the type used by package ints.
+type LengthError = ints.LengthError
+
+// ErrEmpty is returned when a function requires a non-empty slice.
+var ErrEmpty = ints.ErrEmpty
+
+// ErrInvalidArgument is wrapped by errors describing an argument outside
+// the domain of a function.
+var ErrInvalidArgument = ints.ErrInvalidArgument
+
+// DivisionError is returned when a divisor is zero.
+type DivisionError struct {
+	Index int // the index of the first zero divisor
+}
+
+func (e *DivisionError) Error() string {
+	return fmt.Sprintf("safe: division by zero at index %d", e.Index)
+}
+
+// checkLengths returns a *LengthError if the slices do not all have the
+// same length. args holds the comma-separated names of the slices.
+func checkLengths(fn, args string, slices ...[]int) error {
+	if ints.EqualLengths(slices...) {
+		return nil
+	}
+	lens := make([]int, len(slices))
+	for i, s := range slices {
+		lens[i] = len(s)
+	}
+	return &LengthError{Func: fn, Args: strings.Split(args, ", "), Lens: lens}
+}
+
+// checkDivisor returns a *DivisionError if any element of t is zero.
+func checkDivisor(t []int) error {
+	for i, val := range t {
+		if val == 0 {
+			return &DivisionError{Index: i}
+		}
+	}
+	return nil
+}
+
+// Add returns the element-wise sum of all the slices with the results
+// stored in dst, as ints.Add does. Unlike ints.Add, the lengths of all of
+// the slices are checked.
+func Add(dst []int, slices ...[]int) ([]int, error) {
+	all := append([][]int{dst}, slices...)
+	if !ints.EqualLengths(all...) {
+		args := "dst"
+		for i := range slices {
+			args += ", slices[" + strconv.Itoa(i) + "]"
+		}
+		return dst, checkLengths("Add", args, all...)
+	}
+	if len(slices) == 0 {
+		return nil, nil
+	}
+	return ints.Add(dst, slices...), nil
+}
+
+// AddConstTo adds the value c to all of the values in s and stores the
+// result in dst.
+func AddConstTo(dst []int, c int, s []int) ([]int, error) {
+	if err := checkLengths("AddConstTo", "dst, s", dst, s); err != nil {
+		return dst, err
+	}
+	return ints.AddConstTo(dst, c, s), nil
+}
+
+// AddScaled performs dst = dst + alpha * s.
+func AddScaled(dst []int, alpha int, s []int) error {
+	if err := checkLengths("AddScaled", "dst, s", dst, s); err != nil {
+		return err
+	}
+	ints.AddScaled(dst, alpha, s)
+	return nil
+}
+
+// AddScaledTo performs dst = y + alpha * s.
+func AddScaledTo(dst []int, y []int, alpha int, s []int) ([]int, error) {
+	if err := checkLengths("AddScaledTo", "dst, y, s", dst, y, s); err != nil {
+		return dst, err
+	}
+	return ints.AddScaledTo(dst, y, alpha, s), nil
+}
+
+// ApplyIndexed stores f(i, s[i]) in dst[i] for every element of s.
+func ApplyIndexed(dst []int, f func(i, val int) int, s []int) ([]int, error) {
+	if err := checkLengths("ApplyIndexed", "dst, s", dst, s); err != nil {
+		return dst, err
+	}
+	return ints.ApplyIndexed(dst, f, s), nil
+}
+
+// ApplyTo applies the function f to every element of s and stores the
+// result in dst.
+func ApplyTo(dst []int, f func(int) int, s []int) ([]int, error) {
+	if err := checkLengths("ApplyTo", "dst, s", dst, s); err != nil {
+		return dst, err
+	}
+	return ints.ApplyTo(dst, f, s), nil
+}
+
+// ApplyTo2 stores f(a[i], b[i]) in dst[i] for every element of a and b.
+func ApplyTo2(dst []int, f func(int, int) int, a, b []int) ([]int, error) {
+	if err := checkLengths("ApplyTo2", "dst, a, b", dst, a, b); err != nil {
+		return dst, err
+	}
+	return ints.ApplyTo2(dst, f, a, b), nil
+}
+
+// Argsort sorts the elements of s while tracking their original order in
+// inds, as ints.Argsort does.
+func Argsort(s []int, inds []int) error {
+	if err := checkLengths("Argsort", "s, inds", s, inds); err != nil {
+		return err
+	}
+	ints.Argsort(s, inds)
+	return nil
+}
+
+// CumProd finds the cumulative product of the elements of s and stores
+// them in dst. ErrEmpty is returned if s is empty.
+func CumProd(dst, s []int) ([]int, error) {
+	if err := checkLengths("CumProd", "dst, s", dst, s); err != nil {
+		return dst, err
+	}
+	if len(s) == 0 {
+		return dst, ErrEmpty
+	}
+	return ints.CumProd(dst, s), nil
+}
+
+// CumSum finds the cumulative sum of the elements of s and stores them in
+// dst. ErrEmpty is returned if s is empty.
+func CumSum(dst, s []int) ([]int, error) {
+	if err := checkLengths("CumSum", "dst, s", dst, s); err != nil {
+		return dst, err
+	}
+	if len(s) == 0 {
+		return dst, ErrEmpty
+	}
+	return ints.CumSum(dst, s), nil
+}
+
+// Diff finds the difference between consecutive elements of s and stores
+// them in dst.
+func Diff(dst, s []int) ([]int, error) {
+	if err := checkLengths("Diff", "dst, s", dst, s); err != nil {
+		return dst, err
+	}
+	return ints.Diff(dst, s), nil
+}
+
+// Div performs element-wise division between s and t and stores the value
+// in s. A *DivisionError is returned if any element of t is zero.
+func Div(s []int, t []int) error {
+	if err := checkLengths("Div", "s, t", s, t); err != nil {
+		return err
+	}
+	if err := checkDivisor(t); err != nil {
+		return err
+	}
+	ints.Div(s, t)
+	return nil
+}
+
+// DivTo performs element-wise division between s and t and stores the
+// value in dst. A *DivisionError is returned if any element of t is zero.
+func DivTo(dst []int, s []int, t []int) ([]int, error) {
+	if err := checkLengths("DivTo", "dst, s, t", dst, s, t); err != nil {
+		return dst, err
+	}
+	if err := checkDivisor(t); err != nil {
+		return dst, err
+	}
+	return ints.DivTo(dst, s, t), nil
+}
+
+// Dot computes the dot product of s1 and s2.
+func Dot(s1, s2 []int) (int, error) {
+	if err := checkLengths("Dot", "s1, s2", s1, s2); err != nil {
+		return 0, err
+	}
+	return ints.Dot(s1, s2), nil
+}
+
+// Max returns the maximum value in the slice and the location of the
+// maximum value. ErrEmpty is returned if s is empty.
+func Max(s []int) (max int, ind int, err error) {
+	if len(s) == 0 {
+		return 0, 0, ErrEmpty
+	}
+	max, ind = ints.Max(s)
+	return max, ind, nil
+}
+
+// Min returns the minimum value in the slice and the location of the
+// minimum value. ErrEmpty is returned if s is empty.
+func Min(s []int) (min int, ind int, err error) {
+	if len(s) == 0 {
+		return 0, 0, ErrEmpty
+	}
+	min, ind = ints.Min(s)
+	return min, ind, nil
+}
+
+// Mul performs element-wise multiplication between s and t and stores the
+// value in s.
+func Mul(s []int, t []int) error {
+	if err := checkLengths("Mul", "s, t", s, t); err != nil {
+		return err
+	}
+	ints.Mul(s, t)
+	return nil
+}
+
+// MulTo performs element-wise multiplication between s and t and stores
+// the value in dst.
+func MulTo(dst []int, s []int, t []int) ([]int, error) {
+	if err := checkLengths("MulTo", "dst, s, t", dst, s, t); err != nil {
+		return dst, err
+	}
+	return ints.MulTo(dst, s, t), nil
+}
+
+// ScaleTo multiplies every element in s by c and stores the result in dst.
+func ScaleTo(dst []int, c int, s []int) ([]int, error) {
+	if err := checkLengths("ScaleTo", "dst, s", dst, s); err != nil {
+		return dst, err
+	}
+	return ints.ScaleTo(dst, c, s), nil
+}
+
+// Span returns a set of len(dst) equally spaced points between l and u, as
+// ints.Span does. An error wrapping ErrInvalidArgument is returned if
+// len(dst) < 2.
+func Span(dst []int, l, u int) ([]int, error) {
+	if len(dst) < 2 {
+		return dst, fmt.Errorf("%w: destination must have length >1", ErrInvalidArgument)
+	}
+	return ints.Span(dst, l, u), nil
+}
+
+// Sub subtracts, element-wise, t from s and stores the value in s.
+func Sub(s, t []int) error {
+	if err := checkLengths("Sub", "s, t", s, t); err != nil {
+		return err
+	}
+	ints.Sub(s, t)
+	return nil
+}
+
+// SubTo subtracts, element-wise, t from s and stores the value in dst.
+func SubTo(dst, s, t []int) ([]int, error) {
+	if err := checkLengths("SubTo", "dst, s, t", dst, s, t); err != nil {
+		return dst, err
+	}
+	return ints.SubTo(dst, s, t), nil
+}
@@ -0,0 +1,124 @@
+package safe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zacg/ints"
+)
+
+func checkLengthError(t *testing.T, err error, fn string, lens ...int) {
+	var lerr *LengthError
+	if !errors.As(err, &lerr) {
+		t.Errorf("%v: expected a *LengthError, got %v", fn, err)
+		return
+	}
+	if lerr.Func != fn || !ints.Equal(lerr.Lens, lens) || len(lerr.Args) != len(lens) {
+		t.Errorf("%v: wrong error %v", fn, err)
+	}
+}
+
+func TestLengthErrors(t *testing.T) {
+	a, b, c := make([]int, 2), make([]int, 3), make([]int, 3)
+	_, err := Add(a, b, c)
+	checkLengthError(t, err, "Add", 2, 3, 3)
+	_, err = Add(b, c, a)
+	checkLengthError(t, err, "Add", 3, 3, 2)
+	_, err = AddConstTo(a, 2, b)
+	checkLengthError(t, err, "AddConstTo", 2, 3)
+	checkLengthError(t, AddScaled(a, 2, b), "AddScaled", 2, 3)
+	_, err = AddScaledTo(b, c, 2, a)
+	checkLengthError(t, err, "AddScaledTo", 3, 3, 2)
+	_, err = ApplyIndexed(a, func(i, v int) int { return v }, b)
+	checkLengthError(t, err, "ApplyIndexed", 2, 3)
+	_, err = ApplyTo(a, func(v int) int { return v }, b)
+	checkLengthError(t, err, "ApplyTo", 2, 3)
+	_, err = ApplyTo2(b, func(x, y int) int { return x }, c, a)
+	checkLengthError(t, err, "ApplyTo2", 3, 3, 2)
+	checkLengthError(t, Argsort(a, b), "Argsort", 2, 3)
+	_, err = CumProd(a, b)
+	checkLengthError(t, err, "CumProd", 2, 3)
+	_, err = CumSum(a, b)
+	checkLengthError(t, err, "CumSum", 2, 3)
+	_, err = Diff(a, b)
+	checkLengthError(t, err, "Diff", 2, 3)
+	checkLengthError(t, Div(a, b), "Div", 2, 3)
+	_, err = DivTo(a, b, c)
+	checkLengthError(t, err, "DivTo", 2, 3, 3)
+	_, err = Dot(a, b)
+	checkLengthError(t, err, "Dot", 2, 3)
+	checkLengthError(t, Mul(a, b), "Mul", 2, 3)
+	_, err = MulTo(b, c, a)
+	checkLengthError(t, err, "MulTo", 3, 3, 2)
+	_, err = ScaleTo(a, 2, b)
+	checkLengthError(t, err, "ScaleTo", 2, 3)
+	checkLengthError(t, Sub(a, b), "Sub", 2, 3)
+	_, err = SubTo(b, a, c)
+	checkLengthError(t, err, "SubTo", 3, 2, 3)
+}
+
+func TestEmpty(t *testing.T) {
+	if _, _, err := Max(nil); err != ErrEmpty {
+		t.Errorf("Max returned %v", err)
+	}
+	if _, _, err := Min([]int{}); err != ErrEmpty {
+		t.Errorf("Min returned %v", err)
+	}
+	if _, err := CumSum(nil, nil); err != ErrEmpty {
+		t.Errorf("CumSum returned %v", err)
+	}
+	if _, err := CumProd(nil, nil); err != ErrEmpty {
+		t.Errorf("CumProd returned %v", err)
+	}
+}
+
+func TestDivision(t *testing.T) {
+	s := []int{5, 12, 27}
+	err := Div(s, []int{1, 0, 3})
+	var derr *DivisionError
+	if !errors.As(err, &derr) || derr.Index != 1 {
+		t.Errorf("Div returned %v", err)
+	}
+	if !ints.Equal(s, []int{5, 12, 27}) {
+		t.Errorf("Div modified s on error")
+	}
+	if _, err := DivTo(make([]int, 3), s, []int{0, 1, 1}); err == nil {
+		t.Errorf("DivTo did not return an error")
+	}
+	if err := Div(s, []int{1, 2, 3}); err != nil || !ints.Equal(s, []int{5, 6, 9}) {
+		t.Errorf("Div returned %v, %v", s, err)
+	}
+}
+
+func TestSpan(t *testing.T) {
+	if _, err := Span(make([]int, 1), 0, 4); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Span returned %v", err)
+	}
+	dst, err := Span(make([]int, 5), 0, 4)
+	if err != nil || !ints.Equal(dst, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Span returned %v, %v", dst, err)
+	}
+}
+
+func TestResults(t *testing.T) {
+	dst, err := Add(make([]int, 3), []int{1, 2, 3}, []int{4, 5, 6})
+	if err != nil || !ints.Equal(dst, []int{5, 7, 9}) {
+		t.Errorf("Add returned %v, %v", dst, err)
+	}
+	dot, err := Dot([]int{1, 2, 3, 4}, []int{-3, 4, 5, -6})
+	if err != nil || dot != -4 {
+		t.Errorf("Dot returned %v, %v", dot, err)
+	}
+	max, ind, err := Max([]int{3, 4, 1, 7, 5})
+	if err != nil || max != 7 || ind != 3 {
+		t.Errorf("Max returned %v, %v, %v", max, ind, err)
+	}
+	min, ind, err := Min([]int{3, 4, 1, 7, 5})
+	if err != nil || min != 1 || ind != 2 {
+		t.Errorf("Min returned %v, %v, %v", min, ind, err)
+	}
+	cum, err := CumSum(make([]int, 3), []int{1, 2, 3})
+	if err != nil || !ints.Equal(cum, []int{1, 3, 6}) {
+		t.Errorf("CumSum returned %v, %v", cum, err)
+	}
+}
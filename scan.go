@@ -0,0 +1,80 @@
+package ints
+
+// Reduce combines the elements of s from left to right with f, starting
+// from init, and returns f(...f(f(init, s[0]), s[1])..., s[n-1]). Reduce
+// returns init if s is empty.
+func Reduce(f func(acc, val int) int, init int, s []int) int {
+	acc := init
+	for _, val := range s {
+		acc = f(acc, val)
+	}
+	return acc
+}
+
+// Scan performs an inclusive scan of s with f, storing
+// f(...f(s[0], s[1])..., s[i]) in the ith element of dst, so that CumSum
+// is the scan with addition. dst and s may be the same slice.
+// A panic will occur if lengths of arguments do not match.
+func Scan(dst []int, f func(acc, val int) int, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("Scan", "dst, s", len(dst), len(s)))
+	}
+	if len(s) == 0 {
+		return dst
+	}
+	dst[0] = s[0]
+	for i := 1; i < len(s); i++ {
+		dst[i] = f(dst[i-1], s[i])
+	}
+	return dst
+}
+
+// ScanExclusive performs an exclusive scan of s with f starting from init,
+// storing the combination of init and the first i elements of s, but not
+// s[i], in the ith element of dst. dst[0] is init. dst and s may be the
+// same slice. A panic will occur if lengths of arguments do not match.
+func ScanExclusive(dst []int, f func(acc, val int) int, init int, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("ScanExclusive", "dst, s", len(dst), len(s)))
+	}
+	acc := init
+	for i, val := range s {
+		dst[i] = acc
+		acc = f(acc, val)
+	}
+	return dst
+}
+
+// CumMax finds the maximum of the first i elements in s and puts them in
+// place into the ith element of the destination. A panic will occur if
+// lengths of arguments do not match or if s is empty.
+func CumMax(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("CumMax", "dst, s", len(dst), len(s)))
+	}
+	if len(s) == 0 {
+		panic(ErrEmpty)
+	}
+	dst[0] = s[0]
+	for i := 1; i < len(s); i++ {
+		dst[i] = max(dst[i-1], s[i])
+	}
+	return dst
+}
+
+// CumMin finds the minimum of the first i elements in s and puts them in
+// place into the ith element of the destination. A panic will occur if
+// lengths of arguments do not match or if s is empty.
+func CumMin(dst, s []int) []int {
+	if len(dst) != len(s) {
+		panic(lengthError("CumMin", "dst, s", len(dst), len(s)))
+	}
+	if len(s) == 0 {
+		panic(ErrEmpty)
+	}
+	dst[0] = s[0]
+	for i := 1; i < len(s); i++ {
+		dst[i] = min(dst[i-1], s[i])
+	}
+	return dst
+}
@@ -0,0 +1,57 @@
+package ints
+
+import "testing"
+
+func add(a, b int) int { return a + b }
+func mul(a, b int) int { return a * b }
+
+func TestReduce(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	if Reduce(add, 0, s) != Sum(s) {
+		t.Errorf("Reduce with addition does not match Sum")
+	}
+	if Reduce(mul, 1, s) != Prod(s) {
+		t.Errorf("Reduce with multiplication does not match Prod")
+	}
+	if Reduce(add, 7, nil) != 7 {
+		t.Errorf("Reduce of empty slice does not return init")
+	}
+	if Reduce(func(a, b int) int { return 10*a + b }, 0, []int{1, 2, 3}) != 123 {
+		t.Errorf("Reduce does not combine from the left")
+	}
+}
+
+func TestScan(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	dst := make([]int, len(s))
+	AreSlicesEqual(t, CumSum(make([]int, len(s)), s), Scan(dst, add, s), "Scan with addition")
+	AreSlicesEqual(t, CumProd(make([]int, len(s)), s), Scan(dst, mul, s), "Scan with multiplication")
+	if len(Scan(nil, add, nil)) != 0 {
+		t.Errorf("Scan of empty slice not empty")
+	}
+	if !Panics(func() { Scan(make([]int, 2), add, s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+
+	ScanExclusive(dst, add, 0, s)
+	AreSlicesEqual(t, []int{0, 3, 7, 8, 15}, dst, "Exclusive scan")
+	in := []int{3, 4, 1, 7, 5}
+	ScanExclusive(in, mul, 1, in)
+	AreSlicesEqual(t, []int{1, 3, 12, 12, 84}, in, "Exclusive scan in place")
+	if !Panics(func() { ScanExclusive(make([]int, 2), add, 0, s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestCumMaxMin(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	dst := make([]int, len(s))
+	AreSlicesEqual(t, []int{3, 4, 4, 7, 7}, CumMax(dst, s), "Wrong CumMax")
+	AreSlicesEqual(t, []int{3, 3, 1, 1, 1}, CumMin(dst, s), "Wrong CumMin")
+	if !Panics(func() { CumMax(make([]int, 2), s) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+	if !Panics(func() { CumMin(nil, nil) }) {
+		t.Errorf("Did not panic with empty slice")
+	}
+}
@@ -0,0 +1,119 @@
+package ints
+
+import "iter"
+
+// FindSeq returns an iterator over the indices and values of the elements
+// of s for which f returns true. Unlike Find, the matches are produced
+// lazily and no slice of indices is built.
+func FindSeq(f func(int) bool, s []int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for i, val := range s {
+			if f(val) && !yield(i, val) {
+				return
+			}
+		}
+	}
+}
+
+// ChunksSeq returns an iterator over consecutive subslices of s of length n.
+// The final chunk is shorter if len(s) is not a multiple of n. The chunks
+// share the backing array of s. ChunksSeq panics if n < 1.
+func ChunksSeq(s []int, n int) iter.Seq[[]int] {
+	if n < 1 {
+		panic(invalidArgument("chunk length must be positive"))
+	}
+	return func(yield func([]int) bool) {
+		for i := 0; i < len(s); i += n {
+			end := min(i+n, len(s))
+			if !yield(s[i:end:end]) {
+				return
+			}
+		}
+	}
+}
+
+// WindowsSeq returns an iterator over all overlapping subslices of s of
+// length n, in order of their starting index. No windows are produced if
+// len(s) < n. The windows share the backing array of s. WindowsSeq panics
+// if n < 1.
+func WindowsSeq(s []int, n int) iter.Seq[[]int] {
+	if n < 1 {
+		panic(invalidArgument("window length must be positive"))
+	}
+	return func(yield func([]int) bool) {
+		for i := 0; i+n <= len(s); i++ {
+			if !yield(s[i : i+n : i+n]) {
+				return
+			}
+		}
+	}
+}
+
+// PairsSeq returns an iterator over the pairs of elements of s and t with
+// the same index. A panic will occur if lengths of arguments do not match.
+func PairsSeq(s, t []int) iter.Seq2[int, int] {
+	if len(s) != len(t) {
+		panic(lengthError("PairsSeq", "s, t", len(s), len(t)))
+	}
+	return func(yield func(int, int) bool) {
+		for i, val := range s {
+			if !yield(val, t[i]) {
+				return
+			}
+		}
+	}
+}
+
+// SumSeq returns the sum of the values produced by seq.
+func SumSeq(seq iter.Seq[int]) (sum int) {
+	for val := range seq {
+		sum += val
+	}
+	return sum
+}
+
+// MaxSeq returns the maximum value produced by seq and the position of
+// the maximum value in the sequence. If seq produces no values, MaxSeq
+// will panic.
+func MaxSeq(seq iter.Seq[int]) (max int, ind int) {
+	i := 0
+	for val := range seq {
+		if i == 0 || val > max {
+			max = val
+			ind = i
+		}
+		i++
+	}
+	if i == 0 {
+		panic(ErrEmpty)
+	}
+	return max, ind
+}
+
+// MinSeq returns the minimum value produced by seq and the position of
+// the minimum value in the sequence. If seq produces no values, MinSeq
+// will panic.
+func MinSeq(seq iter.Seq[int]) (min int, ind int) {
+	i := 0
+	for val := range seq {
+		if i == 0 || val < min {
+			min = val
+			ind = i
+		}
+		i++
+	}
+	if i == 0 {
+		panic(ErrEmpty)
+	}
+	return min, ind
+}
+
+// CollectInto gathers the values produced by seq. CollectInto will reslice
+// dst to have 0 length, and will append the values to dst.
+func CollectInto(dst []int, seq iter.Seq[int]) []int {
+	dst = dst[:0]
+	for val := range seq {
+		dst = append(dst, val)
+	}
+	return dst
+}
@@ -0,0 +1,105 @@
+package ints
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindSeq(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5}
+	f := func(v int) bool { return v > 3 }
+	var inds, vals []int
+	for i, v := range FindSeq(f, s) {
+		inds = append(inds, i)
+		vals = append(vals, v)
+	}
+	AreSlicesEqual(t, []int{1, 3, 4}, inds, "Wrong indices")
+	AreSlicesEqual(t, []int{4, 7, 5}, vals, "Wrong values")
+
+	// Stopping early
+	n := 0
+	for range FindSeq(f, s) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Did not stop early")
+	}
+}
+
+func TestChunksSeq(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7}
+	var got [][]int
+	for c := range ChunksSeq(s, 3) {
+		got = append(got, c)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Wrong number of chunks %v", len(got))
+	}
+	AreSlicesEqual(t, []int{1, 2, 3}, got[0], "First chunk")
+	AreSlicesEqual(t, []int{7}, got[2], "Last chunk")
+	if cap(got[0]) != 3 {
+		t.Errorf("Chunk capacity extends into the next chunk")
+	}
+	if !Panics(func() { ChunksSeq(s, 0) }) {
+		t.Errorf("Did not panic with zero chunk length")
+	}
+}
+
+func TestWindowsSeq(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	var sums []int
+	for w := range WindowsSeq(s, 2) {
+		sums = append(sums, Sum(w))
+	}
+	AreSlicesEqual(t, []int{3, 5, 7}, sums, "Wrong windows")
+	for range WindowsSeq(s, 5) {
+		t.Errorf("Window produced longer than the slice")
+	}
+	if !Panics(func() { WindowsSeq(s, 0) }) {
+		t.Errorf("Did not panic with zero window length")
+	}
+}
+
+func TestPairsSeq(t *testing.T) {
+	s1 := []int{1, 2, 3, 4}
+	s2 := []int{-3, 4, 5, -6}
+	var dot int
+	for a, b := range PairsSeq(s1, s2) {
+		dot += a * b
+	}
+	if dot != Dot(s1, s2) {
+		t.Errorf("Wrong pairs produced")
+	}
+	if !Panics(func() { PairsSeq(make([]int, 2), make([]int, 3)) }) {
+		t.Errorf("Did not panic with length mismatch")
+	}
+}
+
+func TestSeqReductions(t *testing.T) {
+	s := []int{3, 4, 1, 7, 5, 7, 1}
+	if SumSeq(slices.Values(s)) != Sum(s) {
+		t.Errorf("Wrong sum returned")
+	}
+	if SumSeq(slices.Values([]int{})) != 0 {
+		t.Errorf("Val not returned as default when sequence is empty")
+	}
+	val, ind := MaxSeq(slices.Values(s))
+	if val != 7 || ind != 3 {
+		t.Errorf("MaxSeq returned %v, %v", val, ind)
+	}
+	val, ind = MinSeq(slices.Values(s))
+	if val != 1 || ind != 2 {
+		t.Errorf("MinSeq returned %v, %v", val, ind)
+	}
+	if !Panics(func() { MaxSeq(slices.Values([]int{})) }) {
+		t.Errorf("MaxSeq did not panic with empty sequence")
+	}
+	if !Panics(func() { MinSeq(slices.Values([]int{})) }) {
+		t.Errorf("MinSeq did not panic with empty sequence")
+	}
+
+	dst := []int{9, 9}
+	dst = CollectInto(dst, slices.Values(s[:3]))
+	AreSlicesEqual(t, s[:3], dst, "Wrong values collected")
+}
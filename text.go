@@ -0,0 +1,249 @@
+package ints
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ParseOptions configures ParseInts and AppendParse.
+type ParseOptions struct {
+	// Separators lists the bytes, in addition to white space, that separate
+	// values. Every separator must be preceded and followed by a value on
+	// the same line. A white space byte listed here is a separator rather
+	// than white space, except for newline, which may not be listed. If
+	// Separators is empty, "," is used.
+	Separators string
+	// Base is the base of the values. If Base is 0, values are decimal
+	// unless they carry a 0x, 0o or 0b prefix after the optional sign.
+	Base int
+}
+
+// ParseError records the location of a value that could not be parsed.
+type ParseError struct {
+	Line   int    // 1-based line number
+	Column int    // 1-based byte offset within the line
+	Text   string // the offending text
+	Err    error  // strconv.ErrSyntax, strconv.ErrRange or a description
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("ints: line %d, column %d: parsing %q: %v", e.Line, e.Column, e.Text, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseInts reads the values in r as text. The values are separated by white
+// space or by the separators in opts. A nil opts uses the defaults.
+func ParseInts(r io.Reader, opts *ParseOptions) ([]int, error) {
+	return AppendParse(nil, r, opts)
+}
+
+// AppendParse reads the values in r as ParseInts does and appends them to
+// dst, returning the extended slice. If a value cannot be parsed, the values
+// read before it are returned along with a *ParseError.
+func AppendParse(dst []int, r io.Reader, opts *ParseOptions) ([]int, error) {
+	var o ParseOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Separators == "" {
+		o.Separators = ","
+	}
+	if o.Base != 0 && (o.Base < 2 || o.Base > 36) {
+		panic(invalidArgument("invalid base"))
+	}
+	if isSeparator('\n', o.Separators) {
+		panic(invalidArgument("newline separator"))
+	}
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	var (
+		tok       []byte
+		line      = 1
+		col       = 0
+		tokCol    int
+		lineStart = true // no value or separator read on this line yet
+		needValue bool   // a separator has been read but no value since
+	)
+	// flush parses the pending token, if any.
+	flush := func() error {
+		if len(tok) == 0 {
+			return nil
+		}
+		v, err := parseInt(string(tok), o.Base)
+		if err != nil {
+			return &ParseError{Line: line, Column: tokCol, Text: string(tok), Err: err}
+		}
+		dst = append(dst, v)
+		tok = tok[:0]
+		needValue = false
+		lineStart = false
+		return nil
+	}
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			if err := flush(); err != nil {
+				return dst, err
+			}
+			if needValue {
+				return dst, &ParseError{Line: line, Column: col + 1, Err: ErrEmptyField}
+			}
+			return dst, nil
+		}
+		if err != nil {
+			return dst, err
+		}
+		col++
+		switch {
+		case isSeparator(c, o.Separators):
+			if err := flush(); err != nil {
+				return dst, err
+			}
+			if needValue || lineStart {
+				return dst, &ParseError{Line: line, Column: col, Text: string(c), Err: ErrEmptyField}
+			}
+			needValue = true
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\v' || c == '\f':
+			if err := flush(); err != nil {
+				return dst, err
+			}
+			if c == '\n' {
+				if needValue {
+					return dst, &ParseError{Line: line, Column: col, Err: ErrEmptyField}
+				}
+				line++
+				col = 0
+				lineStart = true
+			}
+		default:
+			if len(tok) == 0 {
+				tokCol = col
+			}
+			tok = append(tok, c)
+		}
+	}
+}
+
+func isSeparator(c byte, seps string) bool {
+	for i := 0; i < len(seps); i++ {
+		if seps[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
+// parseInt parses a single value in the given base. If base is 0, the
+// value may carry a 0x, 0o or 0b prefix after its sign.
+func parseInt(s string, base int) (int, error) {
+	if base == 0 {
+		base = 10
+		sign, body := "", s
+		if len(body) > 0 && (body[0] == '+' || body[0] == '-') {
+			sign, body = body[:1], body[1:]
+		}
+		if len(body) > 2 && body[0] == '0' {
+			switch body[1] {
+			case 'x', 'X':
+				base = 16
+			case 'o', 'O':
+				base = 8
+			case 'b', 'B':
+				base = 2
+			}
+			if base != 10 {
+				body = body[2:]
+				if body[0] == '+' || body[0] == '-' {
+					return 0, strconv.ErrSyntax
+				}
+			}
+		}
+		s = sign + body
+	}
+	v, err := strconv.ParseInt(s, base, strconv.IntSize)
+	if err != nil {
+		return 0, err.(*strconv.NumError).Err
+	}
+	return int(v), nil
+}
+
+// FormatOptions configures Format and AppendFormat.
+type FormatOptions struct {
+	// Base is the base of the values, between 2 and 36. If Base is 0,
+	// values are written in decimal.
+	Base int
+	// Width is the minimum width of each value. Shorter values are padded
+	// on the left with spaces.
+	Width int
+	// Separator is written between values. If Separator is empty, a single
+	// space is used.
+	Separator string
+	// Prefix writes a 0x, 0o or 0b prefix on values in base 16, 8 or 2, so
+	// that the output can be read back by ParseInts with a Base of 0.
+	Prefix bool
+}
+
+// Format returns the text form of s. A nil opts uses the defaults.
+func Format(s []int, opts *FormatOptions) string {
+	return string(AppendFormat(nil, s, opts))
+}
+
+// AppendFormat appends the text form of s to dst, as generated by Format,
+// and returns the extended buffer.
+func AppendFormat(dst []byte, s []int, opts *FormatOptions) []byte {
+	var o FormatOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Base == 0 {
+		o.Base = 10
+	}
+	if o.Base < 2 || o.Base > 36 {
+		panic(invalidArgument("invalid base"))
+	}
+	if o.Separator == "" {
+		o.Separator = " "
+	}
+	var prefix string
+	if o.Prefix {
+		switch o.Base {
+		case 16:
+			prefix = "0x"
+		case 8:
+			prefix = "0o"
+		case 2:
+			prefix = "0b"
+		}
+	}
+	var buf [72]byte
+	for i, val := range s {
+		if i > 0 {
+			dst = append(dst, o.Separator...)
+		}
+		num := buf[:0]
+		if val < 0 {
+			num = append(num, '-')
+		}
+		num = append(num, prefix...)
+		// Format the magnitude as unsigned so that the minimum int,
+		// which has no positive counterpart, is handled.
+		mag := uint64(val)
+		if val < 0 {
+			mag = -mag
+		}
+		num = strconv.AppendUint(num, mag, o.Base)
+		for n := len(num); n < o.Width; n++ {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, num...)
+	}
+	return dst
+}
@@ -0,0 +1,120 @@
+package ints
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseInts(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		opts *ParseOptions
+		want []int
+	}{
+		{"", nil, []int{}},
+		{"1 2  3\n4\t5\n", nil, []int{1, 2, 3, 4, 5}},
+		{"1, 2,3\r\n-4 ,+5", nil, []int{1, 2, 3, -4, 5}},
+		{"0x1f 0o17 0b101 -0X10 17", nil, []int{31, 15, 5, -16, 17}},
+		{"1;2|3", &ParseOptions{Separators: ";|"}, []int{1, 2, 3}},
+		{"1\t2 \t 3\n4\t5", &ParseOptions{Separators: "\t"}, []int{1, 2, 3, 4, 5}},
+		{"ff,-10", &ParseOptions{Base: 16}, []int{255, -16}},
+		{"9223372036854775807 -9223372036854775808", nil, []int{math.MaxInt64, math.MinInt64}},
+	} {
+		got, err := ParseInts(strings.NewReader(test.in), test.opts)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", test.in, err)
+			continue
+		}
+		AreSlicesEqual(t, test.want, got, "Parsing "+strconv.Quote(test.in))
+	}
+}
+
+func TestParseIntsErrors(t *testing.T) {
+	for _, test := range []struct {
+		in        string
+		line, col int
+		err       error
+		n         int
+	}{
+		{"1 2\n3 x4 5", 2, 3, strconv.ErrSyntax, 3},
+		{"1,2\n,3", 2, 1, ErrEmptyField, 2},
+		{"1,,2", 1, 3, ErrEmptyField, 1},
+		{"1,2,\n3", 1, 5, ErrEmptyField, 2},
+		{"1,2,", 1, 5, ErrEmptyField, 2},
+		{"7\n\n  9223372036854775808", 3, 3, strconv.ErrRange, 1},
+		{"0x-5", 1, 1, strconv.ErrSyntax, 0},
+	} {
+		got, err := ParseInts(strings.NewReader(test.in), nil)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%q: expected a *ParseError, got %v", test.in, err)
+			continue
+		}
+		if perr.Line != test.line || perr.Column != test.col {
+			t.Errorf("%q: error at %v:%v, want %v:%v", test.in, perr.Line, perr.Column, test.line, test.col)
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("%q: error %v, want %v", test.in, err, test.err)
+		}
+		if len(got) != test.n {
+			t.Errorf("%q: %v values returned before error, want %v", test.in, len(got), test.n)
+		}
+	}
+}
+
+func TestParseIntsWhitespaceSeparator(t *testing.T) {
+	_, err := ParseInts(strings.NewReader("1\t\t2"), &ParseOptions{Separators: "\t"})
+	var perr *ParseError
+	if !errors.As(err, &perr) || !errors.Is(err, ErrEmptyField) || perr.Column != 3 {
+		t.Errorf("Repeated tab separator returned %v", err)
+	}
+	if !Panics(func() { ParseInts(strings.NewReader("1"), &ParseOptions{Separators: ",\n"}) }) {
+		t.Errorf("Did not panic with newline separator")
+	}
+}
+
+func TestAppendParse(t *testing.T) {
+	dst := []int{1, 2}
+	dst, err := AppendParse(dst, strings.NewReader("3 4"), nil)
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	AreSlicesEqual(t, []int{1, 2, 3, 4}, dst, "Values not appended")
+}
+
+func TestFormat(t *testing.T) {
+	s := []int{0, 7, -255, math.MinInt64}
+	for _, test := range []struct {
+		opts *FormatOptions
+		want string
+	}{
+		{nil, "0 7 -255 -9223372036854775808"},
+		{&FormatOptions{Base: 16, Separator: ","}, "0,7,-ff,-8000000000000000"},
+		{&FormatOptions{Base: 16, Prefix: true}, "0x0 0x7 -0xff -0x8000000000000000"},
+		{&FormatOptions{Base: 2, Prefix: true}, "0b0 0b111 -0b11111111 -0b1" + strings.Repeat("0", 63)},
+		{&FormatOptions{Width: 5, Separator: ""}, "    0     7  -255 -9223372036854775808"},
+	} {
+		if got := Format(s, test.opts); got != test.want {
+			t.Errorf("Format with %+v = %q, want %q", test.opts, got, test.want)
+		}
+	}
+	if got := string(AppendFormat([]byte("x="), []int{1, 2}, &FormatOptions{Separator: ", "})); got != "x=1, 2" {
+		t.Errorf("AppendFormat = %q", got)
+	}
+	if !Panics(func() { Format(s, &FormatOptions{Base: 1}) }) {
+		t.Errorf("Did not panic with invalid base")
+	}
+
+	// Prefixed output reads back
+	for _, base := range []int{2, 8, 10, 16} {
+		str := Format(s, &FormatOptions{Base: base, Prefix: true})
+		got, err := ParseInts(strings.NewReader(str), nil)
+		if err != nil {
+			t.Errorf("Base %v: unexpected error %v", base, err)
+		}
+		AreSlicesEqual(t, s, got, "Round trip in base "+strconv.Itoa(base))
+	}
+}
@@ -0,0 +1,164 @@
+package ints
+
+import "strconv"
+
+// Vector is a strided view of a slice of int. Element i of the vector is
+// Data[Offset+i*Stride], which allows a column of a row-major buffer to be
+// used without copying it out.
+type Vector struct {
+	Data   []int
+	Offset int
+	Stride int
+	N      int
+}
+
+// NewVector returns a Vector of n elements of data starting at offset and
+// separated by stride. NewVector panics if stride < 1 or if the elements
+// do not lie within data.
+func NewVector(data []int, offset, stride, n int) Vector {
+	v := Vector{Data: data, Offset: offset, Stride: stride, N: n}
+	v.check()
+	return v
+}
+
+// check panics if the elements of v do not lie within v.Data.
+func (v Vector) check() {
+	if v.Stride < 1 {
+		panic(invalidArgument("vector stride must be positive"))
+	}
+	if v.N < 0 || v.Offset < 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	if v.N > 0 && v.Offset+(v.N-1)*v.Stride >= len(v.Data) {
+		panic(ErrIndexOutOfRange)
+	}
+}
+
+// Len returns the number of elements of v.
+func (v Vector) Len() int {
+	return v.N
+}
+
+// At returns element i of v.
+func (v Vector) At(i int) int {
+	if i < 0 || i >= v.N {
+		panic(ErrIndexOutOfRange)
+	}
+	return v.Data[v.Offset+i*v.Stride]
+}
+
+// Set sets element i of v to val.
+func (v Vector) Set(i, val int) {
+	if i < 0 || i >= v.N {
+		panic(ErrIndexOutOfRange)
+	}
+	v.Data[v.Offset+i*v.Stride] = val
+}
+
+// Add adds, element-wise, the vectors in vs to dst and returns dst.
+// It panics, before dst is modified, if the lengths of the vectors do not
+// match.
+func (dst Vector) Add(vs ...Vector) Vector {
+	dst.check()
+	for i, v := range vs {
+		v.check()
+		if v.N != dst.N {
+			panic(lengthError("Vector.Add", "dst, vs["+strconv.Itoa(i)+"]", dst.N, v.N))
+		}
+	}
+	for _, v := range vs {
+		for i, j, k := 0, dst.Offset, v.Offset; i < dst.N; i, j, k = i+1, j+dst.Stride, k+v.Stride {
+			dst.Data[j] += v.Data[k]
+		}
+	}
+	return dst
+}
+
+// Scale multiplies every element of v by c.
+func (v Vector) Scale(c int) {
+	v.check()
+	for i, j := 0, v.Offset; i < v.N; i, j = i+1, j+v.Stride {
+		v.Data[j] *= c
+	}
+}
+
+// Dot computes the dot product of v and w.
+// A panic will occur if lengths of arguments do not match.
+func (v Vector) Dot(w Vector) int {
+	v.check()
+	w.check()
+	if v.N != w.N {
+		panic(lengthError("Vector.Dot", "v, w", v.N, w.N))
+	}
+	var sum int
+	for i, j, k := 0, v.Offset, w.Offset; i < v.N; i, j, k = i+1, j+v.Stride, k+w.Stride {
+		sum += v.Data[j] * w.Data[k]
+	}
+	return sum
+}
+
+// Sum returns the sum of the elements of v.
+func (v Vector) Sum() (sum int) {
+	v.check()
+	for i, j := 0, v.Offset; i < v.N; i, j = i+1, j+v.Stride {
+		sum += v.Data[j]
+	}
+	return sum
+}
+
+// Max returns the maximum value in v and the index of the maximum value.
+// If v is empty, Max will panic with ErrEmpty.
+func (v Vector) Max() (max int, ind int) {
+	v.check()
+	if v.N == 0 {
+		panic(ErrEmpty)
+	}
+	max = v.Data[v.Offset]
+	for i, j := 0, v.Offset; i < v.N; i, j = i+1, j+v.Stride {
+		if v.Data[j] > max {
+			max = v.Data[j]
+			ind = i
+		}
+	}
+	return max, ind
+}
+
+// Min returns the minimum value in v and the index of the minimum value.
+// If v is empty, Min will panic with ErrEmpty.
+func (v Vector) Min() (min int, ind int) {
+	v.check()
+	if v.N == 0 {
+		panic(ErrEmpty)
+	}
+	min = v.Data[v.Offset]
+	for i, j := 0, v.Offset; i < v.N; i, j = i+1, j+v.Stride {
+		if v.Data[j] < min {
+			min = v.Data[j]
+			ind = i
+		}
+	}
+	return min, ind
+}
+
+// CumSum finds the cumulative sum of the first i elements of s and puts
+// them into the ith element of dst, returning dst. dst and s may be the
+// same view; otherwise they must not share any element, as elements of s
+// could be overwritten before they are read.
+// A panic will occur if lengths of arguments do not match or if s is
+// empty.
+func (dst Vector) CumSum(s Vector) Vector {
+	dst.check()
+	s.check()
+	if dst.N != s.N {
+		panic(lengthError("Vector.CumSum", "dst, s", dst.N, s.N))
+	}
+	if s.N == 0 {
+		panic(ErrEmpty)
+	}
+	var sum int
+	for i, j, k := 0, dst.Offset, s.Offset; i < dst.N; i, j, k = i+1, j+dst.Stride, k+s.Stride {
+		sum += s.Data[k]
+		dst.Data[j] = sum
+	}
+	return dst
+}
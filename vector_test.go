@@ -0,0 +1,93 @@
+package ints
+
+import "testing"
+
+// rowMajor returns a 4x3 row-major buffer used to take strided views.
+func rowMajor() []int {
+	return []int{
+		3, 1, -3,
+		4, 2, 4,
+		1, 3, 5,
+		7, 4, -6,
+	}
+}
+
+func TestVector(t *testing.T) {
+	data := rowMajor()
+	col := NewVector(data, 0, 3, 4)
+	if col.Len() != 4 || col.At(3) != 7 {
+		t.Errorf("Wrong column view")
+	}
+	col.Set(2, 10)
+	if data[6] != 10 {
+		t.Errorf("Set did not write through to data")
+	}
+	if !Panics(func() { NewVector(data, 1, 3, 5) }) {
+		t.Errorf("Did not panic with vector past end of data")
+	}
+	if !Panics(func() { NewVector(data, 0, 0, 2) }) {
+		t.Errorf("Did not panic with zero stride")
+	}
+	if !Panics(func() { col.At(4) }) {
+		t.Errorf("Did not panic with index out of range")
+	}
+}
+
+func TestVectorOps(t *testing.T) {
+	data := rowMajor()
+	c0 := NewVector(data, 0, 3, 4)
+	c1 := NewVector(data, 1, 3, 4)
+	c2 := NewVector(data, 2, 3, 4)
+
+	if c1.Dot(c2) != Dot([]int{1, 2, 3, 4}, []int{-3, 4, 5, -6}) {
+		t.Errorf("Dot product computed incorrectly")
+	}
+	if c2.Sum() != 0 {
+		t.Errorf("Wrong sum returned")
+	}
+	if val, ind := c2.Max(); val != 5 || ind != 2 {
+		t.Errorf("Max returned %v, %v", val, ind)
+	}
+	if val, ind := c2.Min(); val != -6 || ind != 3 {
+		t.Errorf("Min returned %v, %v", val, ind)
+	}
+
+	c0.Add(c1, c1)
+	c1.Scale(2)
+	c2.CumSum(c2)
+	truth := []int{
+		5, 2, -3,
+		8, 4, 1,
+		7, 6, 6,
+		15, 8, 0,
+	}
+	AreSlicesEqual(t, truth, data, "Wrong result of strided operations")
+
+	// A contiguous vector and a row view
+	row := NewVector(data, 3, 1, 3)
+	dst := NewVector(make([]int, 3), 0, 1, 3)
+	dst.CumSum(row)
+	AreSlicesEqual(t, []int{8, 12, 13}, dst.Data, "Wrong cumsum of row")
+
+	if !Panics(func() { c0.Add(row) }) {
+		t.Errorf("Add did not panic with length mismatch")
+	}
+	ones := NewVector([]int{1, 1, 1}, 0, 1, 3)
+	if !Panics(func() { ones.Add(NewVector([]int{5, 5, 5}, 0, 1, 3), NewVector([]int{5, 5}, 0, 1, 2)) }) {
+		t.Errorf("Add did not panic with a later length mismatch")
+	}
+	AreSlicesEqual(t, []int{1, 1, 1}, ones.Data, "Add modified dst before panicking")
+	if !Panics(func() { c0.Dot(row) }) {
+		t.Errorf("Dot did not panic with length mismatch")
+	}
+	empty := Vector{Stride: 1}
+	if err := recovered(func() { empty.Max() }); err != ErrEmpty {
+		t.Errorf("Max with empty vector panicked with %v", err)
+	}
+	if err := recovered(func() { empty.Min() }); err != ErrEmpty {
+		t.Errorf("Min with empty vector panicked with %v", err)
+	}
+	if err := recovered(func() { empty.CumSum(empty) }); err != ErrEmpty {
+		t.Errorf("CumSum with empty vector panicked with %v", err)
+	}
+}